@@ -0,0 +1,41 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "net/netip"
+
+// Addr boxes an IP address.
+// A netip.Addr does not fit inline and is boxed using the same path as
+// box.Any. String() returns the canonical form from netip.Addr.String.
+func Addr(a netip.Addr) Value {
+	return toIface(a)
+}
+
+// Addr returns the boxed IP address. Strings and byte slices are parsed
+// using netip.ParseAddr. Returns false for any other value, or for a string
+// that does not parse. The zero netip.Addr is returned with true when it
+// was boxed directly.
+func (v Value) Addr() (netip.Addr, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return netip.Addr{}, false
+	}
+	if s, ok := v.numericString(); ok {
+		a, err := netip.ParseAddr(s)
+		return a, err == nil
+	}
+	a, ok := v.assertNonPrimAny().(netip.Addr)
+	return a, ok
+}
+
+// IsAddr returns true if the boxed value is a netip.Addr.
+func (v Value) IsAddr() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(netip.Addr)
+	return ok
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+func TestAddr(t *testing.T) {
+	a4 := netip.MustParseAddr("192.168.1.10")
+	v := Addr(a4)
+	assert(v.IsAddr() && !v.IsString())
+	a, ok := v.Addr()
+	assert(ok && a == a4)
+	assert(v.String() == "192.168.1.10")
+	assert(Any(a4).IsAddr())
+	a, _ = Any(a4).Addr()
+	assert(a == a4)
+
+	a6 := netip.MustParseAddr("2001:0db8:0000:0000:0000:0000:0000:0001")
+	v = Addr(a6)
+	a, ok = v.Addr()
+	assert(ok && a == a6)
+	assert(v.String() == "2001:db8::1")
+	data, err := json.Marshal(v)
+	assert(err == nil && string(data) == `"2001:db8::1"`)
+
+	// the zero Addr
+	v = Addr(netip.Addr{})
+	assert(v.IsAddr() && !v.IsNil())
+	a, ok = v.Addr()
+	assert(ok && !a.IsValid())
+	assert(v.String() == "invalid IP")
+
+	// strings are parsed
+	a, ok = String("::1").Addr()
+	assert(ok && a == netip.MustParseAddr("::1"))
+	assert(!String("::1").IsAddr())
+	a, ok = Bytes([]byte("10.0.0.1")).Addr()
+	assert(ok && a.Is4())
+	_, ok = String("nope").Addr()
+	assert(!ok)
+	_, ok = Int(1).Addr()
+	assert(!ok)
+	_, ok = Nil().Addr()
+	assert(!ok)
+
+	forceIfacePtrs = true
+	a, ok = Addr(a6).Addr()
+	assert(ok && a == a6)
+	forceIfacePtrs = false
+}
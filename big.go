@@ -0,0 +1,129 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"math/big"
+)
+
+// BigInt boxes a *big.Int using the same path as box.Any. The pointer is
+// stored as is, and is not copied. A nil pointer boxes as Nil.
+func BigInt(x *big.Int) Value {
+	if x == nil {
+		return Nil()
+	}
+	return toIface(x)
+}
+
+// BigInt returns the value as a *big.Int.
+// A boxed *big.Int is returned as is, not copied. Integer primitives and
+// floats without a fractional part are converted, as is a *big.Float that
+// is an integer. Strings and byte slices are parsed as base 10 integers.
+// Returns nil for any other value, including Nil and strings that do not
+// parse.
+func (v Value) BigInt() *big.Int {
+	v = v.unwrap()
+	switch v.ptr {
+	case int64Type, durationType, runeType:
+		return big.NewInt(int64(v.ext))
+	case uint64Type, custBitsType, idType:
+		return new(big.Int).SetUint64(v.ext)
+	case float64Type:
+		f := math.Float64frombits(v.ext)
+		if math.IsInf(f, 0) || f != math.Trunc(f) {
+			return nil
+		}
+		x, _ := big.NewFloat(f).Int(nil)
+		return x
+	}
+	if v.isPrim() {
+		return nil
+	}
+	if s, ok := v.numericString(); ok {
+		x, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil
+		}
+		return x
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *big.Int:
+		return vf
+	case *big.Float:
+		if vf.IsInt() {
+			x, _ := vf.Int(nil)
+			return x
+		}
+	}
+	return nil
+}
+
+// IsBigInt returns true if the boxed value is a *big.Int.
+func (v Value) IsBigInt() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(*big.Int)
+	return ok
+}
+
+// BigFloat boxes a *big.Float using the same path as box.Any. The pointer
+// is stored as is, and is not copied. A nil pointer boxes as Nil.
+func BigFloat(x *big.Float) Value {
+	if x == nil {
+		return Nil()
+	}
+	return toIface(x)
+}
+
+// BigFloat returns the value as a *big.Float.
+// A boxed *big.Float is returned as is, not copied. Numeric primitives and
+// a *big.Int are converted, and strings and byte slices are parsed. Returns
+// nil for any other value, including Nil, NaN, and strings that do not
+// parse.
+func (v Value) BigFloat() *big.Float {
+	v = v.unwrap()
+	switch v.ptr {
+	case int64Type, durationType, runeType:
+		return new(big.Float).SetInt64(int64(v.ext))
+	case uint64Type, custBitsType, idType:
+		return new(big.Float).SetUint64(v.ext)
+	case float64Type, percentType:
+		f := math.Float64frombits(v.ext)
+		if math.IsNaN(f) {
+			return nil
+		}
+		return big.NewFloat(f)
+	}
+	if v.isPrim() {
+		return nil
+	}
+	if s, ok := v.numericString(); ok {
+		x, ok := new(big.Float).SetString(s)
+		if !ok {
+			return nil
+		}
+		return x
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *big.Float:
+		return vf
+	case *big.Int:
+		return new(big.Float).SetInt(vf)
+	}
+	return nil
+}
+
+// IsBigFloat returns true if the boxed value is a *big.Float.
+func (v Value) IsBigFloat() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(*big.Float)
+	return ok
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	v := BigInt(x)
+	assert(v.IsBigInt() && !v.IsBigFloat())
+	assert(v.BigInt() == x)
+	assert(v.String() == "123456789012345678901234567890")
+	assert(Any(x).IsBigInt())
+	assert(BigInt(nil).IsNil())
+	assert(Nil().BigInt() == nil)
+
+	y := String("123456789012345678901234567890").BigInt()
+	assert(y != nil && y.Cmp(x) == 0)
+	y = Bytes([]byte("-42")).BigInt()
+	assert(y != nil && y.Int64() == -42)
+	assert(String("12abc").BigInt() == nil)
+	assert(Int64(-5).BigInt().Cmp(big.NewInt(-5)) == 0)
+	y = Uint64(math.MaxUint64).BigInt()
+	assert(y.IsUint64() && y.Uint64() == math.MaxUint64)
+	assert(Float64(1e20).BigInt().String() == "100000000000000000000")
+	assert(Float64(1.5).BigInt() == nil)
+	assert(Float64(math.Inf(1)).BigInt() == nil)
+	assert(Bool(true).BigInt() == nil)
+	assert(Any(Jello{}).BigInt() == nil)
+	assert(BigFloat(big.NewFloat(3)).BigInt().Int64() == 3)
+	assert(BigFloat(big.NewFloat(3.5)).BigInt() == nil)
+	forceIfacePtrs = true
+	assert(BigInt(x).BigInt() == x)
+	forceIfacePtrs = false
+}
+
+func TestBigFloat(t *testing.T) {
+	x := big.NewFloat(1.5)
+	v := BigFloat(x)
+	assert(v.IsBigFloat() && !v.IsBigInt())
+	assert(v.BigFloat() == x)
+	assert(BigFloat(nil).IsNil())
+	assert(Nil().BigFloat() == nil)
+
+	f, _ := String("2.25").BigFloat().Float64()
+	assert(f == 2.25)
+	assert(String("hello").BigFloat() == nil)
+	f, _ = Int(-3).BigFloat().Float64()
+	assert(f == -3)
+	u, _ := Uint64(math.MaxUint64).BigFloat().Uint64()
+	assert(u == math.MaxUint64)
+	assert(Float64(math.NaN()).BigFloat() == nil)
+	f, _ = BigInt(big.NewInt(7)).BigFloat().Float64()
+	assert(f == 7)
+	assert(Bool(true).BigFloat() == nil)
+}
@@ -0,0 +1,432 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// binVersion is the version of the binary format, which is written as the
+// first byte of every encoded value.
+const binVersion = 1
+
+// binary kinds
+const (
+	binNil = iota
+	binBool
+	binInt
+	binUint
+	binFloat
+	binCustom
+	binString
+	binTaggedString
+	binBytes
+	binTime
+	binPercent
+	binDuration
+	binMarshaler
+	binID
+	binRune
+	binColor
+	binEnumSet
+	binSemVer
+	binTaggedBytes
+)
+
+var errBinaryShort = errors.New("box: binary data too short")
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The format is a version byte, followed by a kind byte and the payload.
+// Integers are written as varints, floats as eight little-endian bytes, and
+// strings and byte slices as a uvarint length followed by the contents.
+// A string created by box.StringWithTag also stores its tag.
+//
+// A value boxed with box.Any that implements encoding.BinaryMarshaler is
+// written as its type name followed by its own binary encoding. Its type
+// must be registered using RegisterBinary in order to be unmarshaled. Any
+// other value boxed with box.Any returns an error. A scalar from
+// box.AnyExact is written as the wider value that box.Any would box.
+func (v Value) MarshalBinary() ([]byte, error) {
+	return v.appendBinary(nil)
+}
+
+func (v Value) appendBinary(dst []byte) ([]byte, error) {
+	v = v.unwrap()
+	dst = append(dst, binVersion)
+	switch v.ptr {
+	case nil:
+		return append(dst, binNil), nil
+	case boolType:
+		return append(dst, binBool, byte(v.ext)), nil
+	case int64Type:
+		return binary.AppendVarint(append(dst, binInt), int64(v.ext)), nil
+	case uint64Type:
+		return binary.AppendUvarint(append(dst, binUint), v.ext), nil
+	case float64Type:
+		return binary.LittleEndian.AppendUint64(append(dst, binFloat),
+			v.ext), nil
+	case custBitsType:
+		return binary.AppendUvarint(append(dst, binCustom), v.ext), nil
+	case idType:
+		return binary.AppendUvarint(append(dst, binID), v.ext), nil
+	case runeType:
+		return binary.AppendVarint(append(dst, binRune), int64(v.ext)), nil
+	case colorType:
+		return binary.BigEndian.AppendUint32(append(dst, binColor),
+			uint32(v.ext)), nil
+	case timeType:
+		return appendBinaryTime(dst, v.Time())
+	case percentType:
+		return binary.LittleEndian.AppendUint64(append(dst, binPercent),
+			v.ext), nil
+	case durationType:
+		return binary.AppendVarint(append(dst, binDuration), int64(v.ext)),
+			nil
+	case enumSetType:
+		return appendBinaryEnumSet(dst, maskCodes(v.ext)), nil
+	case semverType:
+		return binary.BigEndian.AppendUint64(append(dst, binSemVer), v.ext),
+			nil
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		if tag := uint16(v.ext >> 8); tag != 0 {
+			return appendBinaryTagged(dst, binTaggedString,
+				v.assertString(), tag), nil
+		}
+		return appendBinaryString(dst, binString, v.assertString()), nil
+	case ptrBytes:
+		if v.ext&bytesTagged != 0 {
+			return appendBinaryTagged(dst, binTaggedBytes,
+				string(v.assertBytes()), v.Tag()), nil
+		}
+		return appendBinaryString(dst, binBytes, string(v.assertBytes())),
+			nil
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string:
+		return appendBinaryString(dst, binString, vf), nil
+	case *taggedString:
+		return appendBinaryTagged(dst, binTaggedString, vf.str, vf.tag), nil
+	case []byte:
+		return appendBinaryString(dst, binBytes, string(vf)), nil
+	case *lazyBytes:
+		return appendBinaryString(dst, binBytes, string(vf.bytes())), nil
+	case *taggedBytes:
+		return appendBinaryTagged(dst, binTaggedBytes, string(vf.bytes),
+			vf.tag), nil
+	case time.Time:
+		return appendBinaryTime(dst, vf)
+	case *enumSet:
+		return appendBinaryEnumSet(dst, vf.codes), nil
+	case encoding.BinaryMarshaler:
+		data, err := vf.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBinaryString(dst, binMarshaler,
+			binaryTypeName(reflect.TypeOf(vf)))
+		dst = binary.AppendUvarint(dst, uint64(len(data)))
+		return append(dst, data...), nil
+	default:
+		return nil, fmt.Errorf("box: cannot marshal %T to binary", vf)
+	}
+}
+
+var binTypes struct {
+	sync.RWMutex
+	m map[string]reflect.Type
+}
+
+func binaryTypeName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		return "*" + binaryTypeName(t.Elem())
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// RegisterBinary records the concrete type of v so that values of that type
+// boxed with box.Any can be restored by UnmarshalBinary. The type, or a
+// pointer to the type, must also implement encoding.BinaryUnmarshaler.
+func RegisterBinary(v encoding.BinaryMarshaler) {
+	t := reflect.TypeOf(v)
+	u := reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	if !t.Implements(u) && !reflect.PtrTo(t).Implements(u) {
+		panic(fmt.Sprintf("box: %s does not implement "+
+			"encoding.BinaryUnmarshaler", t))
+	}
+	binTypes.Lock()
+	if binTypes.m == nil {
+		binTypes.m = make(map[string]reflect.Type)
+	}
+	binTypes.m[binaryTypeName(t)] = t
+	binTypes.Unlock()
+}
+
+func unmarshalBinaryType(name string, data []byte) (Value, error) {
+	binTypes.RLock()
+	t := binTypes.m[name]
+	binTypes.RUnlock()
+	if t == nil {
+		return Value{}, fmt.Errorf("box: binary type %s is not registered",
+			name)
+	}
+	var p reflect.Value
+	if t.Kind() == reflect.Pointer {
+		p = reflect.New(t.Elem())
+	} else {
+		p = reflect.New(t)
+	}
+	err := p.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+	if err != nil {
+		return Value{}, err
+	}
+	if t.Kind() == reflect.Pointer {
+		return Any(p.Interface()), nil
+	}
+	return Any(p.Elem().Interface()), nil
+}
+
+func appendBinaryString(dst []byte, kind byte, s string) []byte {
+	dst = binary.AppendUvarint(append(dst, kind), uint64(len(s)))
+	return append(dst, s...)
+}
+
+func appendBinaryTagged(dst []byte, kind byte, s string, tag uint16,
+) []byte {
+	dst = binary.LittleEndian.AppendUint16(append(dst, kind), tag)
+	dst = binary.AppendUvarint(dst, uint64(len(s)))
+	return append(dst, s...)
+}
+
+func appendBinaryEnumSet(dst []byte, codes []uint64) []byte {
+	dst = binary.AppendUvarint(append(dst, binEnumSet), uint64(len(codes)))
+	for _, code := range codes {
+		dst = binary.AppendUvarint(dst, code)
+	}
+	return dst
+}
+
+func appendBinaryTime(dst []byte, t time.Time) ([]byte, error) {
+	data, err := t.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return appendBinaryString(dst, binTime, string(data)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It decodes data produced by MarshalBinary. Corrupt or truncated data
+// returns an error and leaves the value unchanged.
+func (v *Value) UnmarshalBinary(data []byte) error {
+	nv, n, err := readBinary(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return errors.New("box: unexpected trailing binary data")
+	}
+	*v = nv
+	return nil
+}
+
+// readBinary decodes a single value from the front of data and returns the
+// number of bytes read.
+func readBinary(data []byte) (Value, int, error) {
+	if len(data) < 2 {
+		return Value{}, 0, errBinaryShort
+	}
+	if data[0] != binVersion {
+		return Value{}, 0, fmt.Errorf("box: unknown binary version %d",
+			data[0])
+	}
+	kind, i := data[1], 2
+	switch kind {
+	case binNil:
+		return Nil(), i, nil
+	case binBool:
+		if len(data) < i+1 {
+			return Value{}, 0, errBinaryShort
+		}
+		if data[i] > 1 {
+			return Value{}, 0, errors.New("box: invalid binary bool")
+		}
+		return Bool(data[i] == 1), i + 1, nil
+	case binInt, binDuration, binRune:
+		x, n := binary.Varint(data[i:])
+		if n <= 0 {
+			return Value{}, 0, errBinaryVarint(n)
+		}
+		if kind == binDuration {
+			return Duration(time.Duration(x)), i + n, nil
+		}
+		if kind == binRune {
+			return Rune(rune(x)), i + n, nil
+		}
+		return Int64(x), i + n, nil
+	case binUint, binCustom, binID:
+		x, n := binary.Uvarint(data[i:])
+		if n <= 0 {
+			return Value{}, 0, errBinaryVarint(n)
+		}
+		if kind == binCustom {
+			return CustomBits(x), i + n, nil
+		}
+		if kind == binID {
+			return ID(x), i + n, nil
+		}
+		return Uint64(x), i + n, nil
+	case binFloat, binPercent:
+		if len(data) < i+8 {
+			return Value{}, 0, errBinaryShort
+		}
+		x := math.Float64frombits(binary.LittleEndian.Uint64(data[i:]))
+		if kind == binPercent {
+			return Percent(x), i + 8, nil
+		}
+		return Float64(x), i + 8, nil
+	case binColor:
+		if len(data) < i+4 {
+			return Value{}, 0, errBinaryShort
+		}
+		c := data[i : i+4]
+		return RGBA(c[0], c[1], c[2], c[3]), i + 4, nil
+	case binSemVer:
+		if len(data) < i+8 {
+			return Value{}, 0, errBinaryShort
+		}
+		x := binary.BigEndian.Uint64(data[i:])
+		return SemVer(uint16(x>>32), uint16(x>>16), uint16(x)), i + 8, nil
+	case binString, binBytes:
+		s, n, err := readBinaryString(data[i:])
+		if err != nil {
+			return Value{}, 0, err
+		}
+		if kind == binBytes {
+			return Bytes([]byte(s)), i + n, nil
+		}
+		return String(s), i + n, nil
+	case binTaggedString, binTaggedBytes:
+		if len(data) < i+2 {
+			return Value{}, 0, errBinaryShort
+		}
+		tag := binary.LittleEndian.Uint16(data[i:])
+		i += 2
+		s, n, err := readBinaryString(data[i:])
+		if err != nil {
+			return Value{}, 0, err
+		}
+		if kind == binTaggedBytes {
+			return BytesWithTag([]byte(s), tag), i + n, nil
+		}
+		return StringWithTag(s, tag), i + n, nil
+	case binTime:
+		s, n, err := readBinaryString(data[i:])
+		if err != nil {
+			return Value{}, 0, err
+		}
+		var t time.Time
+		if err := t.UnmarshalBinary([]byte(s)); err != nil {
+			return Value{}, 0, err
+		}
+		return Time(t), i + n, nil
+	case binEnumSet:
+		x, n := binary.Uvarint(data[i:])
+		if n <= 0 {
+			return Value{}, 0, errBinaryVarint(n)
+		}
+		i += n
+		if x > uint64(len(data)-i) {
+			// every code takes at least one byte
+			return Value{}, 0, errBinaryShort
+		}
+		codes := make([]uint64, x)
+		for j := range codes {
+			codes[j], n = binary.Uvarint(data[i:])
+			if n <= 0 {
+				return Value{}, 0, errBinaryVarint(n)
+			}
+			i += n
+		}
+		return EnumSet(codes...), i, nil
+	case binMarshaler:
+		name, n, err := readBinaryString(data[i:])
+		if err != nil {
+			return Value{}, 0, err
+		}
+		i += n
+		s, n, err := readBinaryString(data[i:])
+		if err != nil {
+			return Value{}, 0, err
+		}
+		v, err := unmarshalBinaryType(name, []byte(s))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return v, i + n, nil
+	default:
+		return Value{}, 0, fmt.Errorf("box: unknown binary kind %d", kind)
+	}
+}
+
+func readBinaryString(data []byte) (string, int, error) {
+	x, n := binary.Uvarint(data)
+	if n <= 0 {
+		return "", 0, errBinaryVarint(n)
+	}
+	if x > uint64(len(data)-n) {
+		return "", 0, errBinaryShort
+	}
+	return string(data[n : n+int(x)]), n + int(x), nil
+}
+
+func errBinaryVarint(n int) error {
+	if n == 0 {
+		return errBinaryShort
+	}
+	return errors.New("box: binary varint overflow")
+}
+
+// AppendUint appends the value, as returned by Uint64Wrap, to dst as a
+// fixed-width integer of width bytes in the given byte order. The width
+// must be 1, 2, 4, or 8. A value that does not fit the width is truncated
+// to its low bytes, such that Uint(0x12345).AppendUint(nil, 2,
+// binary.BigEndian) appends 0x23 0x45. Negative ints are written in two's
+// complement.
+// Panics if width is not 1, 2, 4, or 8.
+func (v Value) AppendUint(dst []byte, width int, order binary.ByteOrder,
+) []byte {
+	x := v.Uint64Wrap()
+	switch width {
+	case 1:
+		return append(dst, byte(x))
+	case 2, 4, 8:
+	default:
+		panic(fmt.Sprintf("box: invalid integer width %d", width))
+	}
+	// write in place, as a local buffer passed to order would escape
+	n := len(dst)
+	dst = append(dst, make([]byte, width)...)
+	switch width {
+	case 2:
+		order.PutUint16(dst[n:], uint16(x))
+	case 4:
+		order.PutUint32(dst[n:], uint32(x))
+	case 8:
+		order.PutUint64(dst[n:], x)
+	}
+	return dst
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func binaryTestValues() []Value {
+	return []Value{
+		Nil(),
+		Bool(true),
+		Bool(false),
+		Int64(0),
+		Int64(-1),
+		Int64(math.MinInt64),
+		Int64(math.MaxInt64),
+		Uint64(0),
+		Uint64(math.MaxUint64),
+		Float64(0),
+		Float64(-1.5),
+		Float64(math.Inf(-1)),
+		CustomBits(0xDEADBEEF),
+		String(""),
+		String("hello world"),
+		StringWithTag("hello", 999),
+		Bytes(nil),
+		Bytes([]byte("hello world")),
+	}
+}
+
+func TestBinary(t *testing.T) {
+	for _, v := range binaryTestValues() {
+		data, err := v.MarshalBinary()
+		assert(err == nil)
+		var v2 Value
+		assert(v2.UnmarshalBinary(data) == nil)
+		assert(v2.IsNil() == v.IsNil())
+		assert(v2.IsString() == v.IsString())
+		assert(v2.IsBytes() == v.IsBytes())
+		assert(v2.IsCustomBits() == v.IsCustomBits())
+		assert(v2.Tag() == v.Tag())
+		assert(v2.String() == v.String())
+	}
+	forceIfaceStrs = true
+	for _, v := range []Value{
+		String("hello"), StringWithTag("hello", 10), Bytes([]byte("hello")),
+	} {
+		data, err := v.MarshalBinary()
+		assert(err == nil)
+		var v2 Value
+		assert(v2.UnmarshalBinary(data) == nil)
+		assert(v2.IsString() == v.IsString())
+		assert(v2.IsBytes() == v.IsBytes())
+		assert(v2.Tag() == v.Tag())
+		assert(v2.String() == "hello")
+	}
+	forceIfaceStrs = false
+
+	// decoded bytes must not alias the input buffer
+	data, _ := Bytes([]byte("hello")).MarshalBinary()
+	var v Value
+	assert(v.UnmarshalBinary(data) == nil)
+	data[len(data)-1] = 'x'
+	assert(v.String() == "hello")
+
+	_, err := Any(Jello{1, 2}).MarshalBinary()
+	assert(err != nil)
+}
+
+func TestBinaryCorrupt(t *testing.T) {
+	var v Value
+	assert(v.UnmarshalBinary(nil) != nil)
+	assert(v.UnmarshalBinary([]byte{binVersion}) != nil)
+	assert(v.UnmarshalBinary([]byte{0xFF, binNil}) != nil)
+	assert(v.UnmarshalBinary([]byte{binVersion, 0xFF}) != nil)
+	assert(v.UnmarshalBinary([]byte{binVersion, binBool, 2}) != nil)
+	assert(v.UnmarshalBinary([]byte{binVersion, binNil, 0}) != nil)
+	assert(v.UnmarshalBinary(append([]byte{binVersion, binUint},
+		bytes.Repeat([]byte{0xFF}, 11)...)) != nil)
+	assert(v.UnmarshalBinary([]byte{binVersion, binString, 0xFF, 0xFF,
+		0xFF, 0xFF, 0x0F, 'a'}) != nil)
+	assert(v.IsNil())
+
+	// every truncation of a valid encoding must fail cleanly
+	for _, v := range binaryTestValues() {
+		data, _ := v.MarshalBinary()
+		for i := 0; i < len(data); i++ {
+			var v2 Value
+			assert(v2.UnmarshalBinary(data[:i]) != nil)
+		}
+	}
+}
+
+type binPoint struct{ X, Y int32 }
+
+func (p binPoint) MarshalBinary() ([]byte, error) {
+	return []byte{byte(p.X), byte(p.Y)}, nil
+}
+
+func (p *binPoint) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return errors.New("bad point")
+	}
+	p.X, p.Y = int32(data[0]), int32(data[1])
+	return nil
+}
+
+type binUnregistered struct{ binPoint }
+
+func TestBinaryMarshaler(t *testing.T) {
+	RegisterBinary(binPoint{})
+	RegisterBinary(&binPoint{})
+	for _, v := range []Value{Any(binPoint{1, 2}), Any(&binPoint{3, 4})} {
+		data, err := v.MarshalBinary()
+		assert(err == nil)
+		var v2 Value
+		assert(v2.UnmarshalBinary(data) == nil)
+		assert(v2.Type() == v.Type())
+		assert(v2.String() == v.String())
+		for i := 0; i < len(data); i++ {
+			assert(v2.UnmarshalBinary(data[:i]) != nil)
+		}
+	}
+	data, err := Any(binUnregistered{binPoint{1, 2}}).MarshalBinary()
+	assert(err == nil)
+	var v Value
+	err = v.UnmarshalBinary(data)
+	assert(err != nil && strings.Contains(err.Error(), "not registered"))
+
+	// gob still uses gob for marshalers
+	gob.Register(binUnregistered{})
+	var buf bytes.Buffer
+	in := Any(binUnregistered{binPoint{1, 2}})
+	assert(gob.NewEncoder(&buf).Encode(in) == nil)
+	assert(gob.NewDecoder(&buf).Decode(&v) == nil)
+	assert(v.Any().(binUnregistered).Y == 2)
+}
+
+func FuzzBinary(f *testing.F) {
+	for _, v := range binaryTestValues() {
+		data, _ := v.MarshalBinary()
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v Value
+		if v.UnmarshalBinary(data) != nil {
+			return
+		}
+		data1, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v2 Value
+		if err := v2.UnmarshalBinary(data1); err != nil {
+			t.Fatal(err)
+		}
+		data2, err := v2.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data1, data2) {
+			t.Fatalf("%x != %x", data1, data2)
+		}
+	})
+}
+
+func TestAppendUint(t *testing.T) {
+	be, le := binary.BigEndian, binary.LittleEndian
+	assert(bytes.Equal(Uint(0x1234).AppendUint(nil, 2, be), []byte{0x12, 0x34}))
+	assert(bytes.Equal(Uint(0x1234).AppendUint(nil, 2, le), []byte{0x34, 0x12}))
+	assert(bytes.Equal(Int(0x12345678).AppendUint(nil, 4, be),
+		[]byte{0x12, 0x34, 0x56, 0x78}))
+	assert(bytes.Equal(Int(0x12345678).AppendUint(nil, 4, le),
+		[]byte{0x78, 0x56, 0x34, 0x12}))
+	assert(bytes.Equal(Uint(7).AppendUint(nil, 8, be),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 7}))
+	assert(bytes.Equal(Uint(0x1ff).AppendUint(nil, 1, be), []byte{0xff}))
+
+	// truncated and zero extended
+	assert(bytes.Equal(Uint(0x12345).AppendUint(nil, 2, be),
+		[]byte{0x23, 0x45}))
+	assert(bytes.Equal(Uint(0x45).AppendUint(nil, 4, le),
+		[]byte{0x45, 0, 0, 0}))
+	assert(bytes.Equal(Int(-2).AppendUint(nil, 2, be), []byte{0xff, 0xfe}))
+	assert(bytes.Equal(String("258").AppendUint(nil, 2, be), []byte{1, 2}))
+
+	dst := []byte{0xaa}
+	dst = Uint(1).AppendUint(dst, 2, le)
+	assert(bytes.Equal(dst, []byte{0xaa, 1, 0}))
+	assert(allocs(func() { dst = Uint(1).AppendUint(dst[:0], 4, be) }) == 0)
+
+	var panicked bool
+	func() {
+		defer func() { panicked = recover() != nil }()
+		Uint(1).AppendUint(nil, 3, be)
+	}()
+	assert(panicked)
+}
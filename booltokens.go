@@ -0,0 +1,110 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var boolTokens struct {
+	sync.RWMutex
+	m map[string]bool
+}
+
+// SetBoolTokens sets extra strings that convert to true or false, such as
+// "yes" and "no", or "on" and "off". The tokens are matched without regard
+// to case, and are checked before strconv.ParseBool when a string or byte
+// slice is converted to a bool. Each call replaces the tokens from the
+// previous call, and passing nil for both clears them.
+// It is safe to call concurrently with conversions.
+func SetBoolTokens(truthy, falsy []string) {
+	var m map[string]bool
+	if len(truthy)+len(falsy) > 0 {
+		m = make(map[string]bool, len(truthy)+len(falsy))
+		for _, s := range falsy {
+			m[strings.ToLower(s)] = false
+		}
+		for _, s := range truthy {
+			m[strings.ToLower(s)] = true
+		}
+	}
+	boolTokens.Lock()
+	boolTokens.m = m
+	boolTokens.Unlock()
+}
+
+// parseBool parses a bool using the tokens from SetBoolTokens, falling back
+// to strconv.ParseBool. Surrounding whitespace is ignored.
+func parseBool(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	boolTokens.RLock()
+	m := boolTokens.m
+	boolTokens.RUnlock()
+	if m != nil {
+		if x, ok := m[strings.ToLower(s)]; ok {
+			return x, nil
+		}
+	}
+	return strconv.ParseBool(s)
+}
+
+// looseBools is the fixed set of tokens accepted by BoolLoose, in lower
+// case.
+var looseBools = [...]struct {
+	tok string
+	val bool
+}{
+	{"1", true}, {"t", true}, {"true", true}, {"y", true}, {"yes", true},
+	{"on", true}, {"enabled", true},
+	{"0", false}, {"f", false}, {"false", false}, {"n", false},
+	{"no", false}, {"off", false}, {"disabled", false},
+}
+
+// BoolLoose is like Bool, but a string or byte slice also converts using
+// this fixed set of tokens, matched without regard to case:
+//
+//	true:  1, t, true, y, yes, on, enabled
+//	false: 0, f, false, n, no, off, disabled
+//
+// Other strings, such as "yep", fall back to Bool, and so are false unless
+// they are a token set by SetBoolTokens. Surrounding whitespace is ignored.
+// Matching a token does not allocate.
+func (v Value) BoolLoose() bool {
+	if s, ok := v.StringOk(); ok {
+		if x, ok := parseLooseBool(strings.TrimSpace(s)); ok {
+			return x
+		}
+	} else if b, ok := v.BytesOk(); ok {
+		if x, ok := parseLooseBool(bytes.TrimSpace(b)); ok {
+			return x
+		}
+	}
+	return v.Bool()
+}
+
+func parseLooseBool[T string | []byte](s T) (bool, bool) {
+	for _, lb := range looseBools {
+		if len(s) != len(lb.tok) {
+			continue
+		}
+		i := 0
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c >= 'A' && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			if c != lb.tok[i] {
+				break
+			}
+		}
+		if i == len(s) {
+			return lb.val, true
+		}
+	}
+	return false, false
+}
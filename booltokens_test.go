@@ -0,0 +1,89 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBoolTokens(t *testing.T) {
+	assert(!Any("yes").Bool())
+	_, ok := String("yes").AsBool()
+	assert(!ok)
+
+	SetBoolTokens([]string{"yes", "On"}, []string{"no", "off"})
+	defer SetBoolTokens(nil, nil)
+	assert(Any("yes").Bool())
+	assert(Any("YES").Bool() && Any("on").Bool() && Any("ON").Bool())
+	assert(Bytes([]byte("Yes")).Bool())
+	x, ok := String("off").AsBool()
+	assert(ok && !x)
+	x, ok = String("no").AsBool()
+	assert(ok && !x)
+	assert(String("On").BoolOr(false))
+	// strconv.ParseBool still applies
+	assert(Any("true").Bool() && Any("1").Bool() && !Any("0").Bool())
+	_, ok = String("maybe").AsBool()
+	assert(!ok)
+	var v Value
+	_, err := fmt.Sscanf("yes", "%t", Scanner(&v))
+	assert(err == nil && v.IsBool() && v.Bool())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Any("yes").Bool()
+				SetBoolTokens([]string{"yes"}, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	SetBoolTokens(nil, nil)
+	assert(!Any("yes").Bool())
+	assert(Any("true").Bool())
+}
+
+func TestBoolLoose(t *testing.T) {
+	truthy := []string{"1", "t", "true", "y", "yes", "on", "enabled"}
+	falsy := []string{"0", "f", "false", "n", "no", "off", "disabled"}
+	upper := func(s string) string { return strings.ToUpper(s) }
+	title := func(s string) string { return upper(s[:1]) + s[1:] }
+	for _, s := range truthy {
+		for _, s := range []string{s, upper(s), title(s)} {
+			assert(String(s).BoolLoose() && Bytes([]byte(s)).BoolLoose())
+		}
+	}
+	for _, s := range falsy {
+		for _, s := range []string{s, upper(s), title(s)} {
+			assert(!String(s).BoolLoose() && !Bytes([]byte(s)).BoolLoose())
+		}
+	}
+	assert(String("yEs").BoolLoose() && String("eNaBlEd").BoolLoose())
+	for _, s := range []string{
+		"yep", "yess", "ye", "nope", "onn", "of", "enable", "disable",
+		"", "2", "-1", "\x11", "yes\x00", "ON!",
+	} {
+		assert(!String(s).BoolLoose() && !Bytes([]byte(s)).BoolLoose())
+	}
+	// strict conversions are unchanged
+	assert(!String("yes").Bool() && !String("on").Bool())
+	// non-strings use Bool
+	assert(Int(2).BoolLoose() && !Int(0).BoolLoose() && !Nil().BoolLoose())
+	// tokens from SetBoolTokens still apply in the fallback
+	SetBoolTokens([]string{"yep"}, nil)
+	assert(String("yep").BoolLoose())
+	SetBoolTokens(nil, nil)
+
+	v, b := String("Enabled"), Bytes([]byte("OFF"))
+	assert(allocs(func() { _ = v.BoolLoose() }) == 0)
+	assert(allocs(func() { _ = b.BoolLoose() }) == 0)
+}
@@ -7,13 +7,18 @@ package box
 import (
 	"fmt"
 	"math"
-	"runtime"
+	"math/big"
+	"net/netip"
+	"reflect"
 	"strconv"
-	"sync/atomic"
+	"sync"
+	"time"
+	"unicode/utf8"
 	"unsafe"
 )
 
-var primTypes = [...]byte{0, 1, 2, 3, 4}
+var primTypes = [...]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14,
+	15, 16, 17, 18, 19, 20, 21, 22, 23}
 
 var (
 	boolType     = unsafe.Pointer(&primTypes[0])
@@ -21,11 +26,32 @@ var (
 	uint64Type   = unsafe.Pointer(&primTypes[2])
 	float64Type  = unsafe.Pointer(&primTypes[3])
 	custBitsType = unsafe.Pointer(&primTypes[4])
+	timeType     = unsafe.Pointer(&primTypes[5])
+	percentType  = unsafe.Pointer(&primTypes[6])
+	durationType = unsafe.Pointer(&primTypes[7])
+	idType       = unsafe.Pointer(&primTypes[8])
+	runeType     = unsafe.Pointer(&primTypes[9])
+	colorType    = unsafe.Pointer(&primTypes[10])
+	enumSetType  = unsafe.Pointer(&primTypes[11])
+	semverType   = unsafe.Pointer(&primTypes[12])
+
+	// exact scalars from AnyExact, which must stay last
+	intType       = unsafe.Pointer(&primTypes[13])
+	int8Type      = unsafe.Pointer(&primTypes[14])
+	int16Type     = unsafe.Pointer(&primTypes[15])
+	int32Type     = unsafe.Pointer(&primTypes[16])
+	uintType      = unsafe.Pointer(&primTypes[17])
+	uint8Type     = unsafe.Pointer(&primTypes[18])
+	uint16Type    = unsafe.Pointer(&primTypes[19])
+	uint32Type    = unsafe.Pointer(&primTypes[20])
+	uintptrType   = unsafe.Pointer(&primTypes[21])
+	float32Type   = unsafe.Pointer(&primTypes[22])
+	complex64Type = unsafe.Pointer(&primTypes[23])
 )
 
 func isPrim(ptr unsafe.Pointer) bool {
 	return ptr == nil || (uintptr(ptr) >= uintptr(boolType) &&
-		uintptr(ptr) <= uintptr(custBitsType))
+		uintptr(ptr) <= uintptr(unsafe.Pointer(&primTypes[len(primTypes)-1])))
 }
 
 // Value is a boxed value
@@ -65,36 +91,97 @@ func CustomBits(x uint64) Value {
 	return Value{x, custBitsType}
 }
 
-var plocker uint64
-var ptable map[unsafe.Pointer]struct{}
+// ptable pins the interface types of values boxed through the ptrIface
+// path, which only keep the type as an integer.
+var ptable struct {
+	sync.RWMutex
+	m map[unsafe.Pointer]struct{}
+}
 
-func plock() {
-	for !atomic.CompareAndSwapUint64(&plocker, 0, 1) {
-		runtime.Gosched()
+func psave(p unsafe.Pointer) {
+	// The type is almost always already pinned, so check for it using the
+	// read lock before taking the write lock.
+	ptable.RLock()
+	_, ok := ptable.m[p]
+	ptable.RUnlock()
+	if ok {
+		return
+	}
+	ptable.Lock()
+	if ptable.m == nil {
+		ptable.m = make(map[unsafe.Pointer]struct{})
 	}
+	ptable.m[p] = struct{}{}
+	ptable.Unlock()
 }
-func punlock() {
-	atomic.StoreUint64(&plocker, 0)
+
+// PinnedTypeCount returns the number of distinct interface types that have
+// been pinned by boxing values through box.Any. Each type is pinned once,
+// for the life of the program or until ResetPinnedTypes is called. Values
+// boxed with box.AnyUnpinned do not pin their types.
+func PinnedTypeCount() int {
+	ptable.RLock()
+	n := len(ptable.m)
+	ptable.RUnlock()
+	return n
 }
 
-func psave(p unsafe.Pointer) {
-	plock()
-	if _, ok := ptable[p]; !ok {
-		if ptable == nil {
-			ptable = make(map[unsafe.Pointer]struct{})
+// ResetPinnedTypes unpins all interface types that were pinned by boxing
+// values through box.Any, such as for tests or a controlled restart.
+//
+// This is unsafe to call while any boxed value created before the reset is
+// still in use. A type that was created at runtime, such as by the reflect
+// package, may then be garbage collected while a value still refers to it.
+func ResetPinnedTypes() {
+	ptable.Lock()
+	ptable.m = nil
+	ptable.Unlock()
+}
+
+// Booler is implemented by types that convert themselves to a bool.
+// Value.Bool returns the result of the Bool method of a boxed Booler.
+type Booler interface{ Bool() bool }
+
+// Int64er is implemented by types that convert themselves to an int64.
+// Value.Int64 returns the result of the Int64 method of a boxed Int64er.
+type Int64er interface{ Int64() int64 }
+
+// Uint64er is implemented by types that convert themselves to a uint64.
+// Value.Uint64 returns the result of the Uint64 method of a boxed Uint64er.
+type Uint64er interface{ Uint64() uint64 }
+
+// Float64er is implemented by types that convert themselves to a float64.
+// Value.Float64 returns the result of the Float64 method of a boxed
+// Float64er.
+type Float64er interface{ Float64() float64 }
+
+// Byteser is implemented by types that hold their own byte representation,
+// such as a pre-encoded payload. Value.Bytes returns the result of the Bytes
+// method of a boxed Byteser as is, without formatting the value with fmt.
+type Byteser interface{ Bytes() []byte }
+
+// methodText returns the text of x from its Error or String method, which
+// is what fmt.Sprint would print, without going through fmt. A Byteser that
+// has neither method is read from its Bytes method. A fmt.Formatter or a nil
+// pointer is left to fmt.
+func methodText(x any) (string, bool) {
+	switch x := x.(type) {
+	case fmt.Formatter:
+		return "", false
+	case error:
+		if !isTypedNil(x) {
+			return x.Error(), true
+		}
+	case fmt.Stringer:
+		if !isTypedNil(x) {
+			return x.String(), true
 		}
-		ptable[p] = struct{}{}
+	case Byteser:
+		return string(x.Bytes()), true
 	}
-	punlock()
+	return "", false
 }
 
-type (
-	booler    interface{ Bool() bool }
-	int64er   interface{ Int64() int64 }
-	uint64er  interface{ Uint64() uint64 }
-	float64er interface{ Float64() float64 }
-)
-
 type sface struct {
 	ptr unsafe.Pointer
 	len int
@@ -112,6 +199,15 @@ const maxLen uint64 = 0x7FFFFFFF // int32 -> 2147483647 bytes
 // maxCap is the maximum capacity above the length for byte-slices.
 const maxCap uint64 = 0x7FFFFF // int24 -> 8388607 bytes
 
+// bytesTagged is set in the ext of a byte slice created by BytesWithTag,
+// which then stores the tag in bits 8-23 and the capacity above the length
+// in bits 24-30, limiting it to maxTaggedCap.
+const bytesTagged uint64 = 1 << 31
+
+// maxTaggedCap is the maximum capacity above the length for tagged
+// byte-slices.
+const maxTaggedCap uint64 = 0x7F // 127 bytes
+
 var forceIfaceStrs = false
 var forceIfacePtrs = false
 
@@ -122,6 +218,8 @@ const (
 	ptrBytes
 	ptrIface
 	ptrIfacePtr
+	ptrFloat64s
+	ptrInt64s
 )
 
 // String boxes a string value
@@ -132,8 +230,31 @@ func String(s string) Value {
 	}
 	return Value{
 		ext: (slen << 32) | ptrString,
-		ptr: (*sface)(unsafe.Pointer(&s)).ptr,
+		ptr: nonNilPtr((*sface)(unsafe.Pointer(&s)).ptr),
+	}
+}
+
+// emptyData is pointed to by empty strings and byte slices that have no
+// data pointer of their own, so that they are not mistaken for Nil.
+var emptyData byte
+
+func nonNilPtr(ptr unsafe.Pointer) unsafe.Pointer {
+	if ptr == nil {
+		return unsafe.Pointer(&emptyData)
+	}
+	return ptr
+}
+
+// nilBytesData is pointed to by nil byte slices, and by nil slices from
+// Float64Slice and Int64Slice, so that they are not mistaken for Nil, and
+// come back out as nil slices.
+var nilBytesData byte
+
+func bytesPtr(b []byte) unsafe.Pointer {
+	if b == nil {
+		return unsafe.Pointer(&nilBytesData)
 	}
+	return nonNilPtr((*bface)(unsafe.Pointer(&b)).ptr)
 }
 
 type taggedString struct {
@@ -153,11 +274,14 @@ func StringWithTag(s string, tag uint16) Value {
 	}
 	return Value{
 		ext: (slen << 32) | (uint64(tag) << 8) | ptrString,
-		ptr: (*sface)(unsafe.Pointer(&s)).ptr,
+		ptr: nonNilPtr((*sface)(unsafe.Pointer(&s)).ptr),
 	}
 }
 
-// Bytes boxes a byte slice
+// Bytes boxes a byte slice.
+// An empty or nil slice is boxed as bytes, not as Nil, and a nil slice is
+// returned as nil by Value.Bytes and Value.Any. The binary and msgpack
+// encodings do not keep whether an empty slice was nil.
 func Bytes(b []byte) Value {
 	blen := uint64(len(b))
 	bcap := uint64(cap(b))
@@ -167,7 +291,35 @@ func Bytes(b []byte) Value {
 
 	return Value{
 		ext: (blen << 32) | (bcap-blen)<<8 | ptrBytes,
-		ptr: (*bface)(unsafe.Pointer(&b)).ptr,
+		ptr: bytesPtr(b),
+	}
+}
+
+type taggedBytes struct {
+	tag   uint16
+	bytes []byte
+}
+
+func (tb *taggedBytes) String() string {
+	return string(tb.bytes)
+}
+
+// BytesWithTag boxes a byte slice value and adds a custom tag, such as a
+// code for the encoding of its contents. Tag() returns the tag.
+// The slice is stored inline, like box.Bytes, only when its capacity is at
+// most 127 bytes above its length, because the tag takes most of the bits
+// that would otherwise hold the capacity. Otherwise it's boxed using the
+// same path as box.Any, which allocates.
+func BytesWithTag(b []byte, tag uint16) Value {
+	blen := uint64(len(b))
+	bcap := uint64(cap(b))
+	if forceIfaceStrs || blen > maxLen || bcap-blen > maxTaggedCap {
+		return toIface(&taggedBytes{tag: tag, bytes: b})
+	}
+	return Value{
+		ext: (blen << 32) | bytesTagged | (bcap-blen)<<24 |
+			(uint64(tag) << 8) | ptrBytes,
+		ptr: bytesPtr(b),
 	}
 }
 
@@ -182,12 +334,46 @@ func toIface(v any) Value {
 	}
 	// The interface type is a pointer in the heap or its pointer is too
 	// large to store in 56 bits.
-	// Use a pointer to the interface.
+	return toIfacePtr(v)
+}
+
+// toIfacePtr boxes v using a pointer to the interface, which keeps the type
+// in the interface itself rather than in ptable.
+func toIfacePtr(v any) Value {
 	return Value{ptrIfacePtr, unsafe.Pointer(&v)}
 }
 
 // Any boxes anything
 func Any(v any) Value {
+	return anyOf(v, true)
+}
+
+// AnyUnpinned boxes anything, like box.Any, except that it never pins the
+// interface type of the value. For a value that is not boxed as a
+// primitive, string, or byte slice, Any stores the interface type inline and
+// pins it for the life of the program, as reported by PinnedTypeCount.
+// AnyUnpinned instead stores the whole interface, which keeps its type
+// reachable only for as long as the value is.
+//
+// This is meant for long running hosts that box values of types which may
+// otherwise be collected, such as types created by the reflect package or
+// loaded from plugins. The tradeoff is that AnyUnpinned always allocates one
+// interface for such a value, while Any allocates only when the value does
+// not fit in a pointer.
+func AnyUnpinned(v any) Value {
+	return anyOf(v, false)
+}
+
+// rebox boxes x like the value v was boxed, using AnyUnpinned if v does not
+// pin its type.
+func (v Value) rebox(x any) Value {
+	if v.ext&0xFF == ptrIfacePtr {
+		return AnyUnpinned(x)
+	}
+	return Any(x)
+}
+
+func anyOf(v any, pin bool) Value {
 	switch v := v.(type) {
 	case nil:
 		return Nil()
@@ -195,6 +381,12 @@ func Any(v any) Value {
 		return String(v)
 	case []byte:
 		return Bytes(v)
+	case []float64:
+		return Float64Slice(v)
+	case []int64:
+		return Int64Slice(v)
+	case netip.Addr:
+		return Addr(v)
 	case bool:
 		return Bool(v)
 	case int8:
@@ -223,15 +415,76 @@ func Any(v any) Value {
 		return Float64(float64(v))
 	case float64:
 		return Float64(v)
+	case complex64:
+		return Complex64(v)
+	case complex128:
+		return Complex128(v)
+	case time.Time:
+		return Time(v)
+	case time.Duration:
+		return Duration(v)
+	}
+	if !pin {
+		return toIfacePtr(v)
 	}
 	return toIface(v)
 }
 
+// AnyNilSafe boxes anything, like box.Any, except that a nil pointer, map,
+// slice, channel, function, or interface is boxed as Nil().
+func AnyNilSafe(v any) Value {
+	if isTypedNil(v) {
+		return Nil()
+	}
+	return Any(v)
+}
+
+// IsNilPointer returns true if the boxed value is a typed nil, such as
+// Any((*T)(nil)), Any(map[string]int(nil)), or a nil slice, channel, or
+// function, which box.Any boxes with its type rather than as Nil().
+// A typed nil pointer, map, channel, or function usually has no data
+// pointer once boxed, so IsNil() also returns true for it, but the value
+// is not equal to Nil(). A typed nil slice is not IsNil(). Use
+// box.AnyNilSafe to box every typed nil as Nil().
+func (v Value) IsNilPointer() bool {
+	v = v.unwrap()
+	if v.ptr == nil {
+		// an interface whose data word is nil keeps its type in ext
+		return v.ext&0xFF == ptrIface && v.ext>>8 != 0
+	}
+	if v.isPrim() {
+		return false
+	}
+	switch v.ext & 0xFF {
+	case ptrIface, ptrIfacePtr:
+		return isTypedNil(v.assertNonPrimAny())
+	}
+	return false
+}
+
+// isTypedNil returns true if v is a non-nil interface holding a nil
+// pointer, map, slice, channel, function, or interface.
+func isTypedNil(v any) bool {
+	if v == nil {
+		return false
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan,
+		reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
 func (v Value) isPrim() bool {
 	return isPrim(v.ptr)
 }
 
 func (v Value) assertString() string {
+	if v.ptr == nil || v.ext>>32 == 0 {
+		// never build a string header around a nil pointer
+		return ""
+	}
 	return *(*string)(unsafe.Pointer(&sface{
 		ptr: unsafe.Pointer(v.ptr),
 		len: int(v.ext >> 32),
@@ -239,8 +492,14 @@ func (v Value) assertString() string {
 }
 
 func (v Value) assertBytes() []byte {
+	if v.ptr == nil || v.ptr == unsafe.Pointer(&nilBytesData) {
+		return nil
+	}
 	blen := int(v.ext >> 32)
 	bcap := int((v.ext >> 8) & maxCap)
+	if v.ext&bytesTagged != 0 {
+		bcap = int((v.ext >> 24) & maxTaggedCap)
+	}
 	return *(*[]byte)(unsafe.Pointer(&bface{
 		ptr: unsafe.Pointer(v.ptr),
 		len: blen,
@@ -253,13 +512,22 @@ func (v Value) assertIfacePtr() any {
 }
 
 func (v Value) assertIface() any {
-	return *(*any)(unsafe.Pointer(&[2]uintptr{
-		uintptr(v.ext >> 8),
-		uintptr(v.ptr),
-	}))
+	// Build the interface words in pointer-typed memory. Reinterpreting a
+	// [2]uintptr as an interface hides the data pointer from the compiler,
+	// which is free to drop it.
+	var iface [2]unsafe.Pointer
+	*(*uintptr)(unsafe.Pointer(&iface[0])) = uintptr(v.ext >> 8)
+	iface[1] = v.ptr
+	return *(*any)(unsafe.Pointer(&iface))
 }
 
 // String returns the value as a string.
+// A boxed error or fmt.Stringer returns the result of its Error or String
+// method, as with fmt.Sprint, and a boxed Byteser that has neither method is
+// converted from the result of its Bytes method.
+// Floats are formatted by strconv.FormatFloat using the 'f' format, such
+// that NaN and the infinities are always "NaN", "+Inf", and "-Inf", which
+// strconv.ParseFloat reads back.
 func (v Value) String() string {
 	if !v.isPrim() {
 		if v.ext&0xFF == ptrString {
@@ -268,25 +536,92 @@ func (v Value) String() string {
 		if v.ext&0xFF == ptrBytes {
 			return string(v.assertBytes())
 		}
-		var vf any
-		if v.ext&0xFF == ptrIface {
-			vf = v.assertIface()
-		} else if v.ext&0xFF == ptrIfacePtr {
-			vf = v.assertIfacePtr()
-		}
+		vf := v.assertNonPrimAny()
 		switch vf := vf.(type) {
 		case []byte:
 			return string(vf)
 		case string:
 			return vf
+		case *lazyBytes:
+			return string(vf.bytes())
+		case *taggedBytes:
+			return string(vf.bytes)
+		case *correlated:
+			return vf.v.String()
+		}
+		if s, ok := methodText(vf); ok {
+			return s
 		}
 		return fmt.Sprint(vf)
 	}
 	return v.primToString()
 }
 
+// AppendString appends the string representation of the value to dst and
+// returns the extended buffer. The appended bytes are the same as
+// String(), but numbers, bools, strings, and byte slices are appended
+// without allocating an intermediate string.
+func (v Value) AppendString(dst []byte) []byte {
+	if !v.isPrim() {
+		if v.ext&0xFF == ptrString {
+			return append(dst, v.assertString()...)
+		}
+		if v.ext&0xFF == ptrBytes {
+			return append(dst, v.assertBytes()...)
+		}
+		switch vf := v.assertNonPrimAny().(type) {
+		case []byte:
+			return append(dst, vf...)
+		case string:
+			return append(dst, vf...)
+		case *taggedString:
+			return append(dst, vf.str...)
+		case *lazyBytes:
+			return append(dst, vf.bytes()...)
+		case *taggedBytes:
+			return append(dst, vf.bytes...)
+		case *correlated:
+			return vf.v.AppendString(dst)
+		default:
+			if s, ok := methodText(vf); ok {
+				return append(dst, s...)
+			}
+			return fmt.Append(dst, vf)
+		}
+	}
+	switch v.ptr {
+	case boolType:
+		return strconv.AppendBool(dst, v.ext != 0)
+	case int64Type, intType, int8Type, int16Type, int32Type:
+		return strconv.AppendInt(dst, int64(v.ext), 10)
+	case uint64Type, custBitsType, uintType, uint8Type, uint16Type,
+		uint32Type, uintptrType:
+		return strconv.AppendUint(dst, v.ext, 10)
+	case float64Type:
+		return strconv.AppendFloat(dst, math.Float64frombits(v.ext), 'f', -1,
+			64)
+	case float32Type:
+		return strconv.AppendFloat(dst, math.Float64frombits(v.ext), 'f', -1,
+			32)
+	case timeType:
+		return v.Time().AppendFormat(dst, time.RFC3339Nano)
+	case idType:
+		return appendID(dst, v.ext)
+	case runeType:
+		return utf8.AppendRune(dst, rune(v.ext))
+	case colorType:
+		return appendColor(dst, v.ext)
+	case enumSetType:
+		return appendEnumSet(dst, maskCodes(v.ext))
+	case semverType:
+		return appendSemVer(dst, v.ext)
+	}
+	return append(dst, v.primToString()...)
+}
+
 // Bytes returns the value as a byte slice.
-// When the boxed value is a `[]byte` then those original bytes are returned.
+// When the boxed value is a `[]byte` then those original bytes are returned,
+// and when it is a Byteser the result of its Bytes method is returned as is.
 // Otherwise, the string representation of the value is returned, which will
 // be equivalent to `[]byte(value.String())`.
 func (v Value) Bytes() []byte {
@@ -297,17 +632,24 @@ func (v Value) Bytes() []byte {
 		if v.ext&0xFF == ptrString {
 			return []byte(v.assertString())
 		}
-		var vf any
-		if v.ext&0xFF == ptrIface {
-			vf = v.assertIface()
-		} else if v.ext&0xFF == ptrIfacePtr {
-			vf = v.assertIfacePtr()
-		}
+		vf := v.assertNonPrimAny()
 		switch vf := vf.(type) {
 		case []byte:
 			return vf
 		case string:
 			return []byte(vf)
+		case *lazyBytes:
+			return vf.bytes()
+		case *taggedBytes:
+			return vf.bytes
+		case *correlated:
+			return vf.v.Bytes()
+		case *big.Int:
+			// The Bytes method of a big.Int returns its magnitude, not its
+			// text.
+			return vf.Append(nil, 10)
+		case Byteser:
+			return vf.Bytes()
 		}
 		return []byte(fmt.Sprint(vf))
 	}
@@ -324,12 +666,21 @@ func (v Value) assertNonPrimAny() any {
 	if v.ext&0xFF == ptrString {
 		return v.assertString()
 	}
+	if v.ext&0xFF == ptrFloat64s {
+		return v.assertFloat64s()
+	}
+	if v.ext&0xFF == ptrInt64s {
+		return v.assertInt64s()
+	}
 	return v.assertBytes()
 }
 
 // Any returns the value as an `any/interface{}` type.
 func (v Value) Any() any {
 	if !v.isPrim() {
+		if c, ok := v.assertNonPrimAny().(*correlated); ok {
+			return c.v.Any()
+		}
 		return v.assertNonPrimAny()
 	}
 	return v.primToAny()
@@ -343,14 +694,35 @@ func (v Value) primToString() string {
 	switch v.ptr {
 	case boolType:
 		return strconv.FormatBool(v.ext != 0)
-	case int64Type:
+	case int64Type, intType, int8Type, int16Type, int32Type:
 		return strconv.FormatInt(int64(v.ext), 10)
-	case uint64Type:
+	case uint64Type, uintType, uint8Type, uint16Type, uint32Type,
+		uintptrType:
 		return strconv.FormatUint(v.ext, 10)
 	case float64Type:
 		return strconv.FormatFloat(math.Float64frombits(v.ext), 'f', -1, 64)
+	case float32Type:
+		return strconv.FormatFloat(math.Float64frombits(v.ext), 'f', -1, 32)
+	case complex64Type:
+		return strconv.FormatComplex(complex128(v.complex64()), 'g', -1, 64)
 	case custBitsType:
 		return strconv.FormatUint(v.ext, 10)
+	case timeType:
+		return v.Time().Format(time.RFC3339Nano)
+	case percentType:
+		return v.PercentString(1, false)
+	case durationType:
+		return time.Duration(v.ext).String()
+	case idType:
+		return string(appendID(nil, v.ext))
+	case runeType:
+		return string(rune(v.ext))
+	case colorType:
+		return string(appendColor(nil, v.ext))
+	case enumSetType:
+		return string(appendEnumSet(nil, maskCodes(v.ext)))
+	case semverType:
+		return string(appendSemVer(nil, v.ext))
 	}
 	return "" // nil
 }
@@ -367,11 +739,52 @@ func (v Value) primToAny() any {
 		return math.Float64frombits(v.ext)
 	case custBitsType:
 		return uint64(v.ext)
+	case timeType:
+		return v.Time()
+	case percentType:
+		return math.Float64frombits(v.ext)
+	case durationType:
+		return time.Duration(v.ext)
+	case idType:
+		return uint64(v.ext)
+	case runeType:
+		return rune(v.ext)
+	case colorType:
+		return v.color()
+	case enumSetType:
+		return &enumSet{maskCodes(v.ext)}
+	case semverType:
+		return string(appendSemVer(nil, v.ext))
+	case intType:
+		return int(int64(v.ext))
+	case int8Type:
+		return int8(v.ext)
+	case int16Type:
+		return int16(v.ext)
+	case int32Type:
+		return int32(v.ext)
+	case uintType:
+		return uint(v.ext)
+	case uint8Type:
+		return uint8(v.ext)
+	case uint16Type:
+		return uint16(v.ext)
+	case uint32Type:
+		return uint32(v.ext)
+	case uintptrType:
+		return uintptr(v.ext)
+	case float32Type:
+		return float32(math.Float64frombits(v.ext))
+	case complex64Type:
+		return v.complex64()
 	}
 	return nil // nil
 }
 
-// Float64 returns the value as a float64
+// Float64 returns the value as a float64.
+// Strings are parsed by strconv.ParseFloat, which includes hex floats such
+// as "0x1p-2", or as an integer with a 0x, 0b, or 0o prefix. A boxed
+// Float64er returns the result of its Float64 method.
 func (v Value) Float64() float64 {
 	if v.ptr == float64Type {
 		return math.Float64frombits(v.ext)
@@ -396,51 +809,72 @@ func (v Value) toFloat64() float64 {
 		return math.Float64frombits(v.ext)
 	case v.ptr == custBitsType:
 		return float64(v.ext)
+	case v.ptr == timeType:
+		return float64(int64(v.ext))
+	case v.ptr == percentType:
+		return math.Float64frombits(v.ext)
+	case v.ptr == durationType:
+		return float64(int64(v.ext))
+	case v.ptr == idType:
+		return float64(v.ext)
+	case v.ptr == runeType:
+		return float64(int64(v.ext))
+	case v.ptr == colorType, v.ptr == enumSetType,
+		v.ptr == semverType:
+		return float64(v.ext)
+	}
+	if isExact(v.ptr) {
+		return v.widened().Float64()
 	}
 	switch v := v.assertNonPrimAny().(type) {
 	case string:
-		x, err := strconv.ParseFloat(v, 64)
+		x, err := parseFloat(v)
 		if err == nil {
 			return x
 		}
 	case []byte:
-		x, err := strconv.ParseFloat(string(v), 64)
+		x, err := parseFloat(string(v))
 		if err == nil {
 			return x
 		}
-	case float64er:
+	case Float64er:
 		return v.Float64()
 	}
 	return math.NaN()
 }
 
+// ftou converts f to a uint64 using explicit range checks, because Go
+// leaves out of range float conversions to the platform, such that amd64
+// and arm64 disagree on uint64(-1.5).
 func ftou(f float64) uint64 {
-	if math.IsNaN(f) {
-		return 0
-	}
-	if math.IsInf(f, 0) {
-		if f > 0 {
-			return math.MaxUint64
-		}
+	switch {
+	case f != f, f < 0:
 		return 0
+	case f >= 1<<64:
+		return math.MaxUint64
 	}
 	return uint64(f)
 }
 
+// ftoi is like ftou for an int64.
 func ftoi(f float64) int64 {
-	if math.IsNaN(f) {
+	switch {
+	case f != f:
 		return 0
-	}
-	if math.IsInf(f, 0) {
-		if f > 0 {
-			return math.MaxInt64
-		}
+	case f >= 1<<63:
+		return math.MaxInt64
+	case f < -1<<63:
 		return math.MinInt64
 	}
 	return int64(f)
 }
 
-// Uint64 returns the value as a uint64
+// Uint64 returns the value as a uint64.
+// Negative numbers return 0, such that Int64(-5).Uint64() == 0. Use
+// Uint64Wrap for the two's complement of a negative integer. Floats are
+// truncated toward zero and clamped to the uint64 range, with NaN returning
+// 0, and the results are the same on every platform. Strings are read as by
+// Int64. A boxed Uint64er returns the result of its Uint64 method.
 func (v Value) Uint64() uint64 {
 	if v.ptr == uint64Type {
 		return v.ext
@@ -448,6 +882,23 @@ func (v Value) Uint64() uint64 {
 	return v.toUint64()
 }
 
+// Uint64Wrap is like Uint64, but a negative number wraps to its two's
+// complement, such that Int64(-5).Uint64Wrap() == math.MaxUint64-4.
+// Negative floats are first truncated to an int64. This is how Uint64
+// behaved before negative numbers were clamped to 0.
+func (v Value) Uint64Wrap() uint64 {
+	v = v.unwrap()
+	switch v.ptr {
+	case int64Type, timeType, durationType, runeType:
+		return v.ext
+	case float64Type, percentType:
+		if f := math.Float64frombits(v.ext); f < 0 {
+			return uint64(ftoi(f))
+		}
+	}
+	return v.Uint64()
+}
+
 func (v Value) toUint64() uint64 {
 	switch {
 	case v.ptr == nil:
@@ -457,33 +908,53 @@ func (v Value) toUint64() uint64 {
 			return 0.0
 		}
 		return 1.0
-	case v.ptr == int64Type:
-		return v.ext
 	case v.ptr == uint64Type:
 		return v.ext
 	case v.ptr == float64Type:
 		return ftou(math.Float64frombits(v.ext))
 	case v.ptr == custBitsType:
 		return v.ext
+	case v.ptr == percentType:
+		return ftou(math.Float64frombits(v.ext))
+	case v.ptr == idType:
+		return v.ext
+	case v.ptr == int64Type, v.ptr == timeType, v.ptr == durationType,
+		v.ptr == runeType:
+		if int64(v.ext) < 0 {
+			return 0
+		}
+		return v.ext
+	case v.ptr == colorType, v.ptr == enumSetType,
+		v.ptr == semverType:
+		return v.ext
+	}
+	if isExact(v.ptr) {
+		return v.widened().Uint64()
 	}
 	switch v := v.assertNonPrimAny().(type) {
 	case string:
-		x, err := strconv.ParseUint(v, 10, 64)
-		if err == nil {
+		if x, ok := parseUintLoose(v); ok {
 			return x
 		}
 	case []byte:
-		x, err := strconv.ParseUint(string(v), 10, 64)
-		if err == nil {
+		if x, ok := parseUintLoose(string(v)); ok {
 			return x
 		}
-	case uint64er:
+	case Uint64er:
 		return v.Uint64()
 	}
 	return 0
 }
 
-// Int64 returns the value as an int64
+// Int64 returns the value as an int64.
+// Floats are truncated toward zero and clamped to the int64 range, with NaN
+// returning 0, and the results are the same on every platform. Strings are
+// parsed as decimal, or using a 0x, 0b, or 0o prefix, such that "0xff" is
+// 255. Leading zeros do not mean octal, such that "010" is 10. Surrounding
+// whitespace is ignored, such that " 42 " is 42. A string holding a float,
+// such as "3.0", "2.9", or "1e3", is read like a float, but NaN and the
+// infinities spelled as strings return 0. A boxed Int64er returns the
+// result of its Int64 method.
 func (v Value) Int64() int64 {
 	if v.ptr == int64Type {
 		return int64(v.ext)
@@ -508,25 +979,42 @@ func (v Value) toInt64() int64 {
 		return ftoi(math.Float64frombits(v.ext))
 	case v.ptr == custBitsType:
 		return int64(v.ext)
+	case v.ptr == timeType:
+		return int64(v.ext)
+	case v.ptr == percentType:
+		return ftoi(math.Float64frombits(v.ext))
+	case v.ptr == durationType:
+		return int64(v.ext)
+	case v.ptr == idType:
+		return int64(v.ext)
+	case v.ptr == runeType:
+		return int64(v.ext)
+	case v.ptr == colorType, v.ptr == enumSetType,
+		v.ptr == semverType:
+		return int64(v.ext)
+	}
+	if isExact(v.ptr) {
+		return v.widened().Int64()
 	}
 	switch v := v.assertNonPrimAny().(type) {
 	case string:
-		x, err := strconv.ParseInt(v, 10, 64)
-		if err == nil {
+		if x, ok := parseIntLoose(v); ok {
 			return x
 		}
 	case []byte:
-		x, err := strconv.ParseInt(string(v), 10, 64)
-		if err == nil {
+		if x, ok := parseIntLoose(string(v)); ok {
 			return x
 		}
-	case int64er:
+	case Int64er:
 		return v.Int64()
 	}
 	return 0
 }
 
-// Bool returns the value as a bool
+// Bool returns the value as a bool.
+// Strings are parsed by strconv.ParseBool, or using the tokens set by
+// SetBoolTokens, ignoring surrounding whitespace. A boxed Booler returns the
+// result of its Bool method.
 func (v Value) Bool() bool {
 	if v.ptr == boolType {
 		return *(*bool)(unsafe.Pointer(&v.ext))
@@ -549,19 +1037,36 @@ func (v Value) toBool() bool {
 		return x > 0 || x < 0
 	case v.ptr == custBitsType:
 		return v.ext != 0
+	case v.ptr == timeType:
+		return true
+	case v.ptr == percentType:
+		x := math.Float64frombits(v.ext)
+		return x > 0 || x < 0
+	case v.ptr == durationType:
+		return v.ext != 0
+	case v.ptr == idType:
+		return v.ext != 0
+	case v.ptr == runeType:
+		return v.ext != 0
+	case v.ptr == colorType, v.ptr == enumSetType,
+		v.ptr == semverType:
+		return v.ext != 0
+	}
+	if isExact(v.ptr) {
+		return v.widened().Bool()
 	}
 	switch v := v.assertNonPrimAny().(type) {
 	case string:
-		x, err := strconv.ParseBool(v)
+		x, err := parseBool(v)
 		if err == nil {
 			return x
 		}
 	case []byte:
-		x, err := strconv.ParseBool(string(v))
+		x, err := parseBool(string(v))
 		if err == nil {
 			return x
 		}
-	case booler:
+	case Booler:
 		return v.Bool()
 	}
 	return false
@@ -569,6 +1074,7 @@ func (v Value) toBool() bool {
 
 // IsString returns true if the boxed value is a string.
 func (v Value) IsString() bool {
+	v = v.unwrap()
 	if v.isPrim() {
 		return false
 	}
@@ -588,6 +1094,7 @@ func (v Value) IsString() bool {
 
 // IsBytes returns true if the boxed value is a []byte.
 func (v Value) IsBytes() bool {
+	v = v.unwrap()
 	if v.isPrim() {
 		return false
 	}
@@ -597,28 +1104,86 @@ func (v Value) IsBytes() bool {
 	case ptrString:
 		return false
 	}
-	_, ok := v.assertNonPrimAny().([]byte)
-	return ok
+	switch v.assertNonPrimAny().(type) {
+	case []byte, *lazyBytes, *taggedBytes:
+		return true
+	default:
+		return false
+	}
 }
 
-// IsNil returns true if the boxed value is nil.
+// IsNil returns true if the boxed value is nil. Empty strings and byte
+// slices are not nil.
 func (v Value) IsNil() bool { return v.ptr == nil }
 
+// IsZero returns true if the boxed value is the zero value of its type,
+// such as Nil, Bool(false), Int(0), Float64(0) or negative zero, and empty
+// strings or byte slices. For values boxed with box.Any the underlying
+// value is checked using reflect.Value.IsZero.
+func (v Value) IsZero() bool {
+	v = v.unwrap()
+	switch v.ptr {
+	case nil:
+		return true
+	case float64Type, percentType:
+		return math.Float64frombits(v.ext) == 0
+	case timeType:
+		return false
+	}
+	if v.isPrim() {
+		return v.ext == 0
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return v.ext>>32 == 0
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case []byte:
+		return len(vf) == 0
+	case *taggedString:
+		return len(vf.str) == 0
+	case *lazyBytes:
+		return vf.n == 0
+	case *taggedBytes:
+		return len(vf.bytes) == 0
+	default:
+		return reflect.ValueOf(vf).IsZero()
+	}
+}
+
 // IsCustomBits returns true if the boxed value was created using
 // box.CustomBits.
-func (v Value) IsCustomBits() bool { return v.ptr == custBitsType }
+func (v Value) IsCustomBits() bool { return v.unwrap().ptr == custBitsType }
 
 // IsInt returns true if the boxed value is an int-like primitive:
 // int, int8, int16, int32, int64, byte
-func (v Value) IsInt() bool { return v.ptr == int64Type }
+func (v Value) IsInt() bool { return v.unwrap().ptr == int64Type }
 
 // IsUint returns true if the boxed value is an uint-like primitive:
 // uint, uint8, uint16, uint32, uint64
-func (v Value) IsUint() bool { return v.ptr == uint64Type }
+func (v Value) IsUint() bool { return v.unwrap().ptr == uint64Type }
 
 // IsFloat returns true if the boxed value is an float-like primitive:
 // float32, float64
-func (v Value) IsFloat() bool { return v.ptr == float64Type }
+func (v Value) IsFloat() bool { return v.unwrap().ptr == float64Type }
+
+// IsNaN returns true if the boxed value is a float or percent that is NaN.
+// Returns false for any other kind, including strings such as "NaN".
+func (v Value) IsNaN() bool {
+	v = v.unwrap()
+	return (v.ptr == float64Type || v.ptr == percentType) &&
+		math.IsNaN(math.Float64frombits(v.ext))
+}
+
+// IsInf returns true if the boxed value is a float or percent that is an
+// infinity, according to sign. If sign > 0, IsInf checks for positive
+// infinity, if sign < 0 for negative infinity, and if sign == 0 for either.
+// Returns false for any other kind, including strings such as "Inf".
+func (v Value) IsInf(sign int) bool {
+	v = v.unwrap()
+	return (v.ptr == float64Type || v.ptr == percentType) &&
+		math.IsInf(math.Float64frombits(v.ext), sign)
+}
 
 // IsNumber returns true if the boxed value is an numeric-like primitive:
 // int, int8, int16, int32, int64, byte,
@@ -629,7 +1194,7 @@ func (v Value) IsNumber() bool {
 }
 
 // IsBool returns true if the boxed value is a bool primitive.
-func (v Value) IsBool() bool { return v.ptr == boolType }
+func (v Value) IsBool() bool { return v.unwrap().ptr == boolType }
 
 // Byte boxes an byte
 func Byte(x byte) Value { return Int64(int64(x)) }
@@ -691,8 +1256,10 @@ func (v Value) Uint() uint { return uint(v.Uint64()) }
 // Float32 returns the value as a float32
 func (v Value) Float32() float32 { return float32(v.Float64()) }
 
-// Tag returns the tag from a value created by box.StringWithTag
+// Tag returns the tag from a value created by box.StringWithTag or
+// box.BytesWithTag.
 func (v Value) Tag() uint16 {
+	v = v.unwrap()
 	if v.isPrim() {
 		return 0
 	}
@@ -700,10 +1267,16 @@ func (v Value) Tag() uint16 {
 	case ptrString:
 		return uint16(v.ext >> 8)
 	case ptrBytes:
+		if v.ext&bytesTagged != 0 {
+			return uint16(v.ext >> 8)
+		}
 		return 0
 	default:
-		if s, ok := v.assertNonPrimAny().(*taggedString); ok {
-			return s.tag
+		switch vf := v.assertNonPrimAny().(type) {
+		case *taggedString:
+			return vf.tag
+		case *taggedBytes:
+			return vf.tag
 		}
 		return 0
 	}
@@ -5,8 +5,12 @@
 package box
 
 import (
+	"bytes"
 	"fmt"
 	"math"
+	"math/big"
+	"reflect"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -313,7 +317,7 @@ func TestUnits(t *testing.T) {
 }
 
 func TestPLocks(t *testing.T) {
-	// Tests the psave() with plock/punlock using multiple goroutines.
+	// Tests the psave() locking using multiple goroutines.
 	// Best if used with -race
 	var wg sync.WaitGroup
 	for i := 0; i < 100; i++ {
@@ -504,3 +508,489 @@ func BenchmarkBoxBytes(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkBoxDuration(b *testing.B) {
+	b.ReportAllocs()
+	var d time.Duration
+	for i := 0; i < b.N; i++ {
+		d += Duration(time.Duration(i)).Duration()
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	vals := []Value{
+		Nil(), Bool(true), Bool(false), Int(-123), Uint(123),
+		Float64(1.5), Float64(math.Inf(-1)), CustomBits(7),
+		String("hello"), StringWithTag("hello", 1), Bytes([]byte("hello")),
+		Time(time.Unix(1, 2).UTC()), Percent(0.5), Duration(time.Second),
+		Any(Jello{1, 2}), Any(&Pudding{1, 2}),
+	}
+	for _, v := range vals {
+		assert(string(v.AppendString([]byte("x:"))) == "x:"+v.String())
+	}
+	forceIfaceStrs = true
+	assert(string(String("hello").AppendString(nil)) == "hello")
+	assert(string(StringWithTag("hello", 1).AppendString(nil)) == "hello")
+	assert(string(Bytes([]byte("hello")).AppendString(nil)) == "hello")
+	forceIfaceStrs = false
+	forceIfacePtrs = true
+	assert(string(Any(Jello{1, 2}).AppendString(nil)) == "{1 2}")
+	forceIfacePtrs = false
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	vals := []Value{
+		Bool(true), Int(-123456), Uint(123456), Float64(123.456),
+		String("hello"), Bytes([]byte("hello")),
+	}
+	for _, v := range vals {
+		b.Run(v.Type().String(), func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				buf = v.AppendString(buf[:0])
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	assert(Nil().IsZero())
+	assert(Bool(false).IsZero() && !Bool(true).IsZero())
+	assert(Int(0).IsZero() && !Int(-1).IsZero())
+	assert(Uint(0).IsZero() && !Uint(1).IsZero())
+	assert(Float64(0).IsZero() && !Float64(0.5).IsZero())
+	assert(Float64(math.Copysign(0, -1)).IsZero())
+	assert(!Float64(math.NaN()).IsZero())
+	assert(CustomBits(0).IsZero() && !CustomBits(1).IsZero())
+	assert(Duration(0).IsZero() && !Duration(1).IsZero())
+	assert(!Time(time.Unix(0, 0).UTC()).IsZero())
+	assert(Time(time.Time{}).IsZero())
+	assert(String("").IsZero() && !String("a").IsZero())
+	assert(StringWithTag("", 1).IsZero())
+	assert(Bytes(nil).IsZero())
+	assert(Bytes([]byte{}).IsZero())
+	assert(Bytes(make([]byte, 0, 10)).IsZero())
+	assert(!Bytes([]byte{0}).IsZero())
+	assert(Any(Jello{}).IsZero() && !Any(Jello{1, 0}).IsZero())
+	assert(Any((*Jello)(nil)).IsZero() && !Any(&Jello{}).IsZero())
+	forceIfaceStrs = true
+	assert(String("").IsZero() && !String("a").IsZero())
+	assert(StringWithTag("", 1).IsZero() && !StringWithTag("a", 1).IsZero())
+	assert(Bytes([]byte{}).IsZero() && !Bytes([]byte{0}).IsZero())
+	forceIfaceStrs = false
+	forceIfacePtrs = true
+	assert(Any(Jello{}).IsZero() && !Any(Jello{1, 0}).IsZero())
+	forceIfacePtrs = false
+}
+
+func TestAnyNilSafe(t *testing.T) {
+	assert(AnyNilSafe(nil).IsNil())
+	assert(AnyNilSafe((*int)(nil)).IsNil())
+	assert(AnyNilSafe(error(nil)).IsNil())
+	assert(AnyNilSafe((*Jello)(nil)).IsNil())
+	assert(AnyNilSafe(map[string]int(nil)).IsNil())
+	assert(AnyNilSafe([]int(nil)).IsNil())
+	assert(AnyNilSafe((func())(nil)).IsNil())
+	assert(AnyNilSafe((chan int)(nil)).IsNil())
+	assert(AnyNilSafe([]byte(nil)).IsNil())
+	assert(AnyNilSafe((*Jello)(nil)) == Nil())
+	assert(AnyNilSafe(error(nil)) == Nil())
+
+	assert(AnyNilSafe(5).Int() == 5)
+	assert(AnyNilSafe("hi").String() == "hi")
+	assert(AnyNilSafe(&Jello{1, 2}).Any().(*Jello).Feet == 2)
+	assert(!AnyNilSafe([]int{}).IsNil())
+	assert(!AnyNilSafe(map[string]int{}).IsNil())
+}
+
+func TestIsNilPointer(t *testing.T) {
+	v := Any((*Jello)(nil))
+	assert(v.IsNil() && v.IsNilPointer() && v != Nil())
+	v = Any((map[string]int)(nil))
+	assert(v.IsNil() && v.IsNilPointer() && v != Nil())
+	v = Any([]int(nil))
+	assert(!v.IsNil() && v.IsNilPointer())
+	assert(Any((chan int)(nil)).IsNilPointer())
+	assert(Any((func())(nil)).IsNilPointer())
+	forceIfacePtrs = true
+	v = Any((*Jello)(nil))
+	assert(!v.IsNil() && v.IsNilPointer() && v.TypeName() == "*box.Jello")
+	forceIfacePtrs = false
+
+	assert(!Nil().IsNilPointer())
+	assert(!Any(&Jello{}).IsNilPointer())
+	assert(!Any(map[string]int{}).IsNilPointer())
+	assert(!Any(Jello{}).IsNilPointer())
+	assert(!Int(0).IsNilPointer() && !String("").IsNilPointer())
+	assert(!Bytes(nil).IsNilPointer())
+	assert(!AnyNilSafe((*Jello)(nil)).IsNilPointer())
+}
+
+func TestPinnedTypes(t *testing.T) {
+	type pinA struct{ x int }
+	type pinB struct{ x int }
+	Any(pinA{1})
+	n := PinnedTypeCount()
+	assert(n > 0)
+	Any(pinA{2})
+	assert(PinnedTypeCount() == n)
+	Any(pinB{1})
+	assert(PinnedTypeCount() == n+1)
+	ResetPinnedTypes()
+	assert(PinnedTypeCount() == 0)
+	v := Any(pinA{3})
+	assert(PinnedTypeCount() == 1)
+	assert(v.Any().(pinA).x == 3)
+	Int(1)
+	String("hello")
+	assert(PinnedTypeCount() == 1)
+}
+
+func TestAnyUnpinned(t *testing.T) {
+	type unpinned struct{ x int }
+	ResetPinnedTypes()
+	v := AnyUnpinned(unpinned{1})
+	assert(PinnedTypeCount() == 0)
+	assert(v.Any().(unpinned).x == 1 && v.Kind() == KindAny)
+	assert(v.Type() == reflect.TypeOf(unpinned{}))
+	assert(v.DeepEqual(AnyUnpinned(unpinned{1})))
+	p := &unpinned{2}
+	v = AnyUnpinned(p)
+	assert(v.Any() == p && !v.IsNil() && !v.IsNilPointer())
+	assert(AnyUnpinned((*unpinned)(nil)).IsNilPointer())
+	assert(allocs(func() { _ = AnyUnpinned(p) }) == 1)
+	m := map[string]int{"a": 1}
+	v = AnyUnpinned(m)
+	c := v.DeepClone()
+	c.Any().(map[string]int)["a"] = 2
+	assert(m["a"] == 1)
+	assert(PinnedTypeCount() == 0)
+
+	// values that Any boxes without the interface type are unchanged
+	assert(AnyUnpinned(nil) == Nil())
+	assert(AnyUnpinned(1) == Int(1))
+	assert(AnyUnpinned("hi") == String("hi"))
+	assert(AnyUnpinned(1.5) == Float64(1.5))
+	assert(PinnedTypeCount() == 0)
+	Any(unpinned{1})
+	assert(PinnedTypeCount() == 1)
+}
+
+func BenchmarkBoxIfaceParallel(b *testing.B) {
+	// Boxes a rotating set of 50 concrete types across GOMAXPROCS
+	// goroutines, which all check the pinned type table.
+	var vals []any
+	for i := 0; i < 50; i++ {
+		typ := reflect.ArrayOf(i+1, reflect.TypeOf(byte(0)))
+		vals = append(vals, reflect.New(typ).Interface())
+	}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int
+		for pb.Next() {
+			Any(vals[i%len(vals)])
+			i++
+		}
+	})
+}
+
+func TestIsNaNInf(t *testing.T) {
+	assert(Float64(math.NaN()).IsNaN())
+	assert(Percent(math.NaN()).IsNaN())
+	assert(!Float64(1).IsNaN() && !Float64(math.Inf(1)).IsNaN())
+	assert(!String("NaN").IsNaN() && !Int(0).IsNaN() && !Nil().IsNaN())
+	assert(!Any(math.NaN()).IsInf(0) && Any(math.NaN()).IsNaN())
+
+	pos, neg := Float64(math.Inf(1)), Float64(math.Inf(-1))
+	assert(pos.IsInf(0) && pos.IsInf(1) && !pos.IsInf(-1))
+	assert(neg.IsInf(0) && neg.IsInf(-1) && !neg.IsInf(1))
+	assert(Percent(math.Inf(1)).IsInf(1))
+	assert(!Float64(math.MaxFloat64).IsInf(0))
+	assert(!String("Inf").IsInf(0) && !String("-Inf").IsInf(-1))
+	assert(!Uint(math.MaxUint64).IsInf(0))
+}
+
+func TestNonFinite(t *testing.T) {
+	nan := Float64(math.NaN())
+	pos, neg := Float64(math.Inf(1)), Float64(math.Inf(-1))
+	assert(nan.IsNaN() && !nan.IsInf(0))
+	assert(pos.IsInf(1) && neg.IsInf(-1) && !pos.IsNaN())
+
+	assert(nan.String() == "NaN" && string(nan.Bytes()) == "NaN")
+	assert(pos.String() == "+Inf" && string(pos.Bytes()) == "+Inf")
+	assert(neg.String() == "-Inf" && string(neg.Bytes()) == "-Inf")
+	assert(string(neg.AppendString(nil)) == "-Inf")
+	for _, v := range []Value{nan, pos, neg} {
+		f, err := strconv.ParseFloat(v.String(), 64)
+		assert(err == nil && Float64(f).String() == v.String())
+		assert(String(v.String()).Float64() == f || f != f)
+	}
+
+	assert(nan.Int64() == 0 && nan.Uint64() == 0)
+	assert(nan.Int() == 0 && nan.Int8() == 0 && nan.Uint32() == 0)
+	assert(pos.Int64() == math.MaxInt64 && neg.Int64() == math.MinInt64)
+	assert(pos.Uint64() == math.MaxUint64 && neg.Uint64() == 0)
+	assert(!nan.Bool() && pos.Bool() && neg.Bool())
+	assert(math.IsNaN(nan.Float64()) && math.IsInf(pos.Float64(), 1))
+	assert(math.IsInf(float64(neg.Float32()), -1))
+	assert(math.IsNaN(Percent(math.NaN()).Float64()))
+	assert(Percent(math.NaN()).Int64() == 0)
+
+	for _, v := range []Value{nan, pos, neg} {
+		_, ok := v.AsInt64()
+		assert(!ok)
+		_, ok = v.AsUint64()
+		assert(!ok)
+		_, err := v.Int64E()
+		assert(err != nil)
+		f, ok := v.AsFloat64()
+		assert(ok && (f != f || math.IsInf(f, 0)))
+	}
+	_, ok := nan.AsBool()
+	assert(!ok)
+}
+
+func TestOutOfRangeConversions(t *testing.T) {
+	// negative numbers clamp to zero
+	assert(Int64(-5).Uint64() == 0 && Int64(-5).Uint() == 0)
+	assert(Int64(-5).Uint8() == 0 && Int64(-1).Uint32() == 0)
+	assert(Duration(-1).Uint64() == 0 && Rune(-1).Uint64() == 0)
+	assert(Int64(5).Uint64() == 5)
+	assert(Int64(-5).Uint64Wrap() == math.MaxUint64-4)
+	assert(Int64(5).Uint64Wrap() == 5)
+	assert(Uint64(math.MaxUint64).Uint64Wrap() == math.MaxUint64)
+
+	// float to uint boundaries
+	for _, c := range []struct {
+		f float64
+		x uint64
+	}{
+		{-1.5, 0}, {-0.5, 0}, {math.Copysign(0, -1), 0}, {0.5, 0},
+		{1.5, 1}, {-1e300, 0}, {1e300, math.MaxUint64},
+		{1 << 63, 1 << 63}, {1<<64 - 2048, 1<<64 - 2048},
+		{1 << 64, math.MaxUint64}, {math.Inf(-1), 0},
+		{math.Inf(1), math.MaxUint64}, {math.NaN(), 0},
+	} {
+		assert(Float64(c.f).Uint64() == c.x)
+		assert(Percent(c.f).Uint64() == c.x)
+	}
+	assert(Float64(-1.5).Uint64Wrap() == math.MaxUint64)
+	assert(Float64(-1e300).Uint64Wrap() == 1<<63)
+	assert(Float64(2.5).Uint64Wrap() == 2)
+
+	// float to int boundaries
+	for _, c := range []struct {
+		f float64
+		x int64
+	}{
+		{-1.5, -1}, {1.5, 1}, {-1 << 63, math.MinInt64},
+		{-1<<63 - 4096, math.MinInt64}, {1<<63 - 1024, 1<<63 - 1024},
+		{1 << 63, math.MaxInt64}, {1e300, math.MaxInt64},
+		{-1e300, math.MinInt64}, {math.NaN(), 0},
+	} {
+		assert(Float64(c.f).Int64() == c.x)
+		assert(Percent(c.f).Int64() == c.x)
+	}
+}
+
+func TestBytesWithTag(t *testing.T) {
+	check := func(v Value, b []byte, tag uint16) {
+		t.Helper()
+		assert(v.IsBytes() && !v.IsString() && v.Kind() == KindBytes)
+		assert(v.Tag() == tag && string(v.Bytes()) == string(b))
+		assert(v.String() == string(b) && v.Len() == len(b))
+		assert(string(v.AppendString(nil)) == string(b))
+		got, ok := v.BytesOk()
+		assert(ok && string(got) == string(b))
+		data, err := v.MarshalBinary()
+		assert(err == nil)
+		var v2 Value
+		assert(v2.UnmarshalBinary(data) == nil)
+		assert(v2.IsBytes() && v2.Tag() == tag && v2.String() == string(b))
+		assert(v.Clone().Tag() == tag && v.Clone().String() == string(b))
+		assert(v.EqualIdentity(BytesWithTag(b, tag)))
+		assert(tag == 0 || !v.EqualIdentity(Bytes(b)))
+		assert(v.TypeName() == "[]uint8")
+	}
+	b := make([]byte, 5, 5+127)
+	copy(b, "hello")
+	for _, tag := range []uint16{0, 1, 0x7f, 0x80, 0xffff} {
+		v := BytesWithTag(b, tag)
+		assert(v.Layout().Tag == ptrBytes)
+		got := v.Bytes()
+		assert(&got[0] == &b[0] && cap(got) == cap(b))
+		assert(allocs(func() { v = BytesWithTag(b, tag) }) == 0)
+		check(v, b, tag)
+
+		// too much spare capacity to stay inline
+		big := make([]byte, 5, 5+128)
+		copy(big, "hello")
+		v = BytesWithTag(big, tag)
+		assert(v.Layout().Tag != ptrBytes)
+		got = v.Bytes()
+		assert(&got[0] == &big[0] && cap(got) == cap(big))
+		check(v, big, tag)
+
+		forceIfaceStrs = true
+		check(BytesWithTag(b, tag), b, tag)
+		forceIfaceStrs = false
+
+		check(BytesWithTag(nil, tag), nil, tag)
+		assert(BytesWithTag(nil, tag).IsZero())
+	}
+	assert(BytesWithTag([]byte("hi"), 3).GoString() ==
+		`box.BytesWithTag([]byte{0x68, 0x69}, 3)`)
+	assert(Bytes([]byte("hi")).Tag() == 0)
+	big := make([]byte, 1, 1<<20)
+	assert(Bytes(big).Tag() == 0 && cap(Bytes(big).Bytes()) == 1<<20)
+}
+
+type payload struct{ buf []byte }
+
+func (p *payload) Bytes() []byte { return p.buf }
+
+type namedPayload struct{ payload }
+
+func (p *namedPayload) String() string { return "named" }
+
+func TestByteser(t *testing.T) {
+	p := &payload{buf: []byte("encoded")}
+	v := Any(p)
+	b := v.Bytes()
+	assert(string(b) == "encoded" && &b[0] == &p.buf[0])
+	assert(allocs(func() { _ = v.Bytes() }) == 0)
+	assert(v.String() == "encoded")
+	assert(string(v.AppendString([]byte("x"))) == "xencoded")
+	assert(v.Kind() == KindAny && v.Any() == p)
+
+	// a fmt.Stringer keeps its own text, but Bytes still uses its buffer
+	np := &namedPayload{payload{buf: []byte("encoded")}}
+	v = Any(np)
+	b = v.Bytes()
+	assert(string(b) == "encoded" && &b[0] == &np.buf[0])
+	assert(v.String() == "named")
+	assert(string(v.AppendString(nil)) == "named")
+
+	// the Bytes method of a big.Int is its magnitude, not its text
+	v = BigInt(big.NewInt(-258))
+	assert(string(v.Bytes()) == "-258" && v.String() == "-258")
+
+	var bb bytes.Buffer
+	bb.WriteString("buffered")
+	assert(string(Any(&bb).Bytes()) == "buffered")
+}
+
+type convType struct{}
+
+func (convType) Bool() bool       { return true }
+func (convType) Int64() int64     { return -7 }
+func (convType) Uint64() uint64   { return 7 }
+func (convType) Float64() float64 { return 7.5 }
+func (convType) String() string   { return "conv" }
+
+var (
+	_ Booler    = convType{}
+	_ Int64er   = convType{}
+	_ Uint64er  = convType{}
+	_ Float64er = convType{}
+	_ Byteser   = (*payload)(nil)
+)
+
+type stringerPtr struct{ s string }
+
+func (p *stringerPtr) String() string { return p.s }
+
+func TestConversionInterfaces(t *testing.T) {
+	v := Any(convType{})
+	assert(v.Bool() && v.Int64() == -7 && v.Uint64() == 7)
+	assert(v.Float64() == 7.5)
+	assert(v.String() == "conv" && string(v.AppendString(nil)) == "conv")
+	assert(string(v.Bytes()) == "conv")
+
+	// String uses the method directly, as fmt.Sprint would
+	v = Any(&stringerPtr{"hello"})
+	assert(v.String() == "hello")
+	assert(allocs(func() { _ = v.String() }) == 0)
+	v = Any(fmt.Errorf("oops"))
+	assert(v.String() == "oops")
+
+	// a nil receiver is left to fmt
+	forceIfacePtrs = true
+	v = Any((*stringerPtr)(nil))
+	forceIfacePtrs = false
+	assert(v.String() == fmt.Sprint((*stringerPtr)(nil)))
+}
+
+func TestEmptyIsNotNil(t *testing.T) {
+	check := func() {
+		for _, v := range []Value{
+			String(""), Any(""), StringWithTag("", 1), String("abc"[:0]),
+		} {
+			assert(v.IsString() && !v.IsNil() && v.Kind() == KindString)
+			assert(v.String() == "" && v != Nil() && v.Valid())
+			j, err := v.MarshalJSON()
+			assert(err == nil && string(j) == `""`)
+			b, err := v.MarshalBinary()
+			assert(err == nil)
+			var w Value
+			assert(w.UnmarshalBinary(b) == nil && w.IsString() && !w.IsNil())
+		}
+		for _, v := range []Value{Bytes([]byte{}), Any([]byte{})} {
+			assert(v.IsBytes() && !v.IsNil() && v.Kind() == KindBytes)
+			assert(len(v.Bytes()) == 0 && v != Nil())
+		}
+	}
+	check()
+	forceIfaceStrs = true
+	check()
+	forceIfaceStrs = false
+}
+
+func TestEmptyBytes(t *testing.T) {
+	check := func() {
+		for _, b := range [][]byte{nil, {}, make([]byte, 0, 10)} {
+			for _, v := range []Value{Bytes(b), Any(b), BytesWithTag(b, 3)} {
+				assert(v.IsBytes() && !v.IsNil() && v.Kind() == KindBytes)
+				assert(v != Nil() && v.Valid() && v.String() == "")
+				assert(v.Tag() == 0 || v.Tag() == 3)
+				out := v.Bytes()
+				assert(len(out) == 0 && (out == nil) == (b == nil))
+				assert(cap(out) == cap(b))
+				if x, ok := v.Any().([]byte); ok {
+					assert(len(x) == 0 && (x == nil) == (b == nil))
+				} else {
+					assert(v.Tag() == 3)
+				}
+				assert((v.Clone().Bytes() == nil) == (b == nil))
+			}
+		}
+	}
+	check()
+	forceIfaceStrs = true
+	check()
+	forceIfaceStrs = false
+	assert(BytesWithTag(nil, 3).Tag() == 3)
+	assert(Bytes(nil) != Bytes([]byte{}))
+}
+
+func TestEmptyHeaders(t *testing.T) {
+	// headers without a data pointer unbox as empty, without reading
+	// through the pointer
+	assert(Value{ext: ptrString}.assertString() == "")
+	assert(Value{ext: 5<<32 | ptrString}.assertString() == "")
+	assert(Value{ext: ptrBytes}.assertBytes() == nil)
+	assert(Value{ext: 5<<32 | ptrBytes}.assertBytes() == nil)
+
+	for _, v := range []Value{String(""), Bytes(nil), Bytes([]byte{})} {
+		assert(v.String() == "" && len(v.Bytes()) == 0)
+		assert(string(v.AppendString([]byte("x"))) == "x")
+		assert(v.Len() == 0 && v.IsZero() && !v.IsNil())
+		j, err := v.MarshalJSON()
+		assert(err == nil && (string(j) == `""` || string(j) == "null"))
+		_ = v.GoString()
+		_ = v.Clone()
+	}
+	assert(String("").String() == "")
+	assert(Bytes(nil).Bytes() == nil && Bytes([]byte{}).Bytes() != nil)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// AppendBytes appends p to the byte slice of the value and returns the
+// updated value, which allows a boxed byte slice to be reused as a buffer.
+// Like the built-in append, the bytes are written into the spare capacity
+// of the slice when there is room, without allocating, and otherwise into
+// a newly allocated array. The result may therefore share memory with v.
+// The tag of a value from box.BytesWithTag is kept.
+//
+// Any other value is first converted using Value.Bytes into a new slice,
+// such that String("ab").AppendBytes([]byte("c")) is Bytes([]byte("abc")).
+func (v Value) AppendBytes(p []byte) Value {
+	if c, ok := v.correlated(); ok {
+		return c.v.AppendBytes(p).WithCorrelation(c.id)
+	}
+	b := v.Bytes()
+	if !v.IsBytes() {
+		b = b[:len(b):len(b)]
+	}
+	return v.withBytes(append(b, p...))
+}
+
+// Grow makes sure that the byte slice of the value has room for at least n
+// more bytes, and returns the updated value. The value is returned as is
+// when it already has the room, and otherwise its bytes are copied into a
+// newly allocated array. Any other value is first converted as by
+// AppendBytes.
+func (v Value) Grow(n int) Value {
+	if n < 0 {
+		panic("box: negative count")
+	}
+	if c, ok := v.correlated(); ok {
+		return c.v.Grow(n).WithCorrelation(c.id)
+	}
+	if !v.IsBytes() {
+		v = v.AppendBytes(nil)
+	}
+	b := v.Bytes()
+	if cap(b)-len(b) >= n {
+		return v
+	}
+	nb := make([]byte, len(b), 2*cap(b)+n)
+	copy(nb, b)
+	return v.withBytes(nb)
+}
+
+func (v Value) correlated() (*correlated, bool) {
+	switch v.ext & 0xFF {
+	case ptrIface, ptrIfacePtr:
+		if !v.isPrim() {
+			c, ok := v.assertNonPrimAny().(*correlated)
+			return c, ok
+		}
+	}
+	return nil, false
+}
+
+// withBytes boxes b as a byte slice that keeps the tag of v, if any.
+func (v Value) withBytes(b []byte) Value {
+	if !v.isPrim() {
+		switch v.ext & 0xFF {
+		case ptrBytes:
+			if v.ext&bytesTagged != 0 {
+				return BytesWithTag(b, v.Tag())
+			}
+		case ptrIface, ptrIfacePtr:
+			if vf, ok := v.assertNonPrimAny().(*taggedBytes); ok {
+				return BytesWithTag(b, vf.tag)
+			}
+		}
+	}
+	return Bytes(b)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "testing"
+
+func TestAppendBytes(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	v := Bytes(buf)
+	v = v.AppendBytes([]byte("hello"))
+	assert(string(v.Bytes()) == "hello" && v.IsBytes())
+	v = v.AppendBytes([]byte(" world"))
+	b := v.Bytes()
+	assert(string(b) == "hello world" && cap(b) == 64 && &b[0] == &buf[:1][0])
+
+	// appends within the spare capacity do not allocate
+	p := []byte("x")
+	assert(allocs(func() { _ = Bytes(buf[:0]).AppendBytes(p) }) == 0)
+	assert(allocs(func() { _ = Bytes(buf[:0]).Grow(64) }) == 0)
+
+	// appends past the capacity copy into a new array
+	v = Bytes(buf[:60:64]).AppendBytes([]byte("0123456789"))
+	b = v.Bytes()
+	assert(len(b) == 70 && string(b[60:]) == "0123456789" && &b[0] != &buf[:1][0])
+
+	// the tag is kept
+	v = BytesWithTag(make([]byte, 0, 8), 7).AppendBytes([]byte("abc"))
+	assert(string(v.Bytes()) == "abc" && v.Tag() == 7)
+	v = BytesWithTag(make([]byte, 0, 1000), 9).AppendBytes([]byte("abc"))
+	assert(string(v.Bytes()) == "abc" && v.Tag() == 9)
+	v = v.AppendBytes(make([]byte, 2000))
+	assert(v.Len() == 2003 && v.Tag() == 9)
+
+	// other values are converted to new bytes
+	s := String("ab")
+	v = s.AppendBytes([]byte("c"))
+	assert(v.IsBytes() && string(v.Bytes()) == "abc" && s.String() == "ab")
+	assert(string(Int(12).AppendBytes([]byte("3")).Bytes()) == "123")
+	pl := &payload{buf: make([]byte, 2, 8)}
+	v = Any(pl).AppendBytes([]byte("zz"))
+	assert(string(v.Bytes()) == "\x00\x00zz")
+	assert(string(pl.buf[:4]) == "\x00\x00\x00\x00")
+
+	v = Bytes([]byte("ab")).WithCorrelation(5).AppendBytes([]byte("c"))
+	id, ok := v.Correlation()
+	assert(ok && id == 5 && string(v.Bytes()) == "abc")
+}
+
+func TestGrow(t *testing.T) {
+	v := Bytes([]byte("abc")).Grow(100)
+	b := v.Bytes()
+	assert(string(b) == "abc" && cap(b)-len(b) >= 100)
+	w := v.Grow(50)
+	assert(&w.Bytes()[0] == &b[0])
+	v = BytesWithTag([]byte("x"), 3).Grow(500)
+	assert(v.Tag() == 3 && string(v.Bytes()) == "x" && cap(v.Bytes()) >= 501)
+	v = String("hi").Grow(10)
+	assert(v.IsBytes() && string(v.Bytes()) == "hi" && cap(v.Bytes()) >= 12)
+	defer func() { assert(recover() != nil) }()
+	Bytes(nil).Grow(-1)
+}
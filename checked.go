@@ -0,0 +1,125 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "math"
+
+// Int8Checked returns the value as an int8, and zero and false when the
+// value does not fit an int8 exactly. The value must first convert with
+// AsInt64, so fractional floats and strings that do not parse also return
+// false.
+func (v Value) Int8Checked() (int8, bool) {
+	x, ok := v.checkedInt(math.MinInt8, math.MaxInt8)
+	return int8(x), ok
+}
+
+// Int16Checked is like Int8Checked for an int16.
+func (v Value) Int16Checked() (int16, bool) {
+	x, ok := v.checkedInt(math.MinInt16, math.MaxInt16)
+	return int16(x), ok
+}
+
+// Int32Checked is like Int8Checked for an int32.
+func (v Value) Int32Checked() (int32, bool) {
+	x, ok := v.checkedInt(math.MinInt32, math.MaxInt32)
+	return int32(x), ok
+}
+
+// Int64Checked is like Int8Checked for an int64. It is the same as AsInt64.
+func (v Value) Int64Checked() (int64, bool) {
+	return v.AsInt64()
+}
+
+// IntChecked is like Int8Checked for an int, using the word size of the
+// platform.
+func (v Value) IntChecked() (int, bool) {
+	x, ok := v.checkedInt(math.MinInt, math.MaxInt)
+	return int(x), ok
+}
+
+// Uint8Checked returns the value as a uint8, and zero and false when the
+// value does not fit a uint8 exactly, such as a negative number. The value
+// must first convert with AsUint64.
+func (v Value) Uint8Checked() (uint8, bool) {
+	x, ok := v.checkedUint(math.MaxUint8)
+	return uint8(x), ok
+}
+
+// Uint16Checked is like Uint8Checked for a uint16.
+func (v Value) Uint16Checked() (uint16, bool) {
+	x, ok := v.checkedUint(math.MaxUint16)
+	return uint16(x), ok
+}
+
+// Uint32Checked is like Uint8Checked for a uint32.
+func (v Value) Uint32Checked() (uint32, bool) {
+	x, ok := v.checkedUint(math.MaxUint32)
+	return uint32(x), ok
+}
+
+// Uint64Checked is like Uint8Checked for a uint64. It is the same as
+// AsUint64.
+func (v Value) Uint64Checked() (uint64, bool) {
+	return v.AsUint64()
+}
+
+// UintChecked is like Uint8Checked for a uint, using the word size of the
+// platform.
+func (v Value) UintChecked() (uint, bool) {
+	x, ok := v.checkedUint(math.MaxUint)
+	return uint(x), ok
+}
+
+// Float32Checked returns the value as a float32, and zero and false when
+// the value is not exactly representable as a float32, such as 0.1, 1e300,
+// or an integer above 2^24 that is not a multiple of a large enough power
+// of two. NaN and the infinities are representable.
+func (v Value) Float32Checked() (float32, bool) {
+	n, ok := v.Number()
+	if !ok {
+		f, ok := v.AsFloat64()
+		if !ok || float64(float32(f)) != f {
+			return 0, false
+		}
+		return float32(f), true
+	}
+	var f float32
+	switch n.kind {
+	case 'i':
+		x := int64(n.bits)
+		f = float32(x)
+		ok = f >= -(1<<63) && f < 1<<63 && int64(f) == x
+	case 'u':
+		f = float32(n.bits)
+		ok = f < 1<<64 && uint64(f) == n.bits
+	default:
+		x := math.Float64frombits(n.bits)
+		f = float32(x)
+		ok = math.IsNaN(x) || float64(f) == x
+	}
+	if !ok {
+		return 0, false
+	}
+	return f, true
+}
+
+// checkedInt returns the value when it converts exactly to an integer in
+// [lo, hi], and zero and false otherwise.
+func (v Value) checkedInt(lo, hi int64) (int64, bool) {
+	x, ok := v.AsInt64()
+	if !ok || x < lo || x > hi {
+		return 0, false
+	}
+	return x, true
+}
+
+// checkedUint is like checkedInt for [0, hi].
+func (v Value) checkedUint(hi uint64) (uint64, bool) {
+	x, ok := v.AsUint64()
+	if !ok || x > hi {
+		return 0, false
+	}
+	return x, true
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	// Each column of fits is a target, in the order int8, int16, int32,
+	// int64, int, uint8, uint16, uint32, uint64, uint, float32.
+	tests := []struct {
+		v    Value
+		fits string
+	}{
+		{Int(0), "11111111111"},
+		{Int(-1), "11111000001"},
+		{Int(math.MaxInt8), "11111111111"},
+		{Int(math.MaxInt8 + 1), "01111111111"},
+		{Int(math.MinInt8), "11111000001"},
+		{Int(math.MinInt8 - 1), "01111000001"},
+		{Int(math.MaxUint8), "01111111111"},
+		{Int(math.MaxUint8 + 1), "01111011111"},
+		{Int(math.MaxInt16), "01111011111"},
+		{Int(math.MaxInt16 + 1), "00111011111"},
+		{Int(math.MinInt16), "01111000001"},
+		{Int(math.MinInt16 - 1), "00111000001"},
+		{Int(math.MaxUint16), "00111011111"},
+		{Int(math.MaxUint16 + 1), "00111001111"},
+		{Int(1 << 24), "00111001111"},
+		{Int(1<<24 + 1), "00111001110"},
+		{Int(math.MaxInt32), "00111001110"},
+		{Int(math.MaxInt32 + 1), "00011001111"},
+		{Int(math.MinInt32), "00111000001"},
+		{Int(math.MinInt32 - 1), "00011000000"},
+		{Int(math.MaxUint32), "00011001110"},
+		{Int(math.MaxUint32 + 1), "00011000111"},
+		{Int(math.MaxInt64), "00011000110"},
+		{Int(math.MinInt64), "00011000001"},
+		{Uint(0), "11111111111"},
+		{Uint(math.MaxUint8 + 1), "01111011111"},
+		{Uint(math.MaxInt64), "00011000110"},
+		{Uint(math.MaxInt64 + 1), "00000000111"},
+		{Uint(math.MaxUint64), "00000000110"},
+		{Float64(0), "11111111111"},
+		{Float64(-2), "11111000001"},
+		{Float64(300), "01111011111"},
+		{Float64(1.5), "00000000001"},
+		{Float64(-0.5), "00000000001"},
+		{Float64(0.1), "00000000000"},
+		{Float64(1 << 31), "00011001111"},
+		{Float64(1 << 63), "00000000111"},
+		{Float64(-1 << 63), "00011000001"},
+		{Float64(1 << 64), "00000000001"},
+		{Float64(1e300), "00000000000"},
+		{Float64(math.NaN()), "00000000001"},
+		{Float64(math.Inf(1)), "00000000001"},
+		{Float64(math.Inf(-1)), "00000000001"},
+		{String("200"), "01111111111"},
+		{String("-5"), "11111000001"},
+		{String("2.5"), "00000000001"},
+		{String("abc"), "00000000000"},
+		{Nil(), "00000000000"},
+	}
+	for i, tt := range tests {
+		var got []bool
+		add := func(ok bool) { got = append(got, ok) }
+		_, ok := tt.v.Int8Checked()
+		add(ok)
+		_, ok = tt.v.Int16Checked()
+		add(ok)
+		_, ok = tt.v.Int32Checked()
+		add(ok)
+		_, ok = tt.v.Int64Checked()
+		add(ok)
+		_, ok = tt.v.IntChecked()
+		add(ok)
+		_, ok = tt.v.Uint8Checked()
+		add(ok)
+		_, ok = tt.v.Uint16Checked()
+		add(ok)
+		_, ok = tt.v.Uint32Checked()
+		add(ok)
+		_, ok = tt.v.Uint64Checked()
+		add(ok)
+		_, ok = tt.v.UintChecked()
+		add(ok)
+		_, ok = tt.v.Float32Checked()
+		add(ok)
+		for j, c := range tt.fits {
+			if strconv.IntSize == 32 && (j == 4 || j == 9) {
+				continue
+			}
+			if got[j] != (c == '1') {
+				t.Fatalf("%d: %#v: target %d: expected %c", i, tt.v, j, c)
+			}
+		}
+	}
+
+	// values
+	x8, ok := Int(-128).Int8Checked()
+	assert(ok && x8 == -128)
+	x8, ok = Int(128).Int8Checked()
+	assert(!ok && x8 == 0)
+	u16, ok := Float64(65535).Uint16Checked()
+	assert(ok && u16 == 65535)
+	u16, ok = Int(-1).Uint16Checked()
+	assert(!ok && u16 == 0)
+	x32, ok := String("-7").Int32Checked()
+	assert(ok && x32 == -7)
+	f, ok := Float64(0.5).Float32Checked()
+	assert(ok && f == 0.5)
+	f, ok = Float64(0.1).Float32Checked()
+	assert(!ok && f == 0)
+	f, ok = Uint(1 << 40).Float32Checked()
+	assert(ok && f == 1<<40)
+	f, ok = Float64(math.NaN()).Float32Checked()
+	assert(ok && f != f)
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "reflect"
+
+// cloner is implemented by boxed values that can copy themselves.
+type cloner interface{ Clone() any }
+
+// Clone returns a copy of the value that does not share memory with the
+// original. The contents of strings and byte slices are copied into newly
+// allocated memory, which means that Clone allocates for those values.
+// Primitives are returned unchanged. A value boxed with box.Any that has a
+// `Clone() any` method is boxed again using the result of that method, and
+// any other value is returned unchanged. A value from box.AnyUnpinned is
+// boxed again with box.AnyUnpinned.
+func (v Value) Clone() Value {
+	if v.isPrim() {
+		return v
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return StringWithTag(cloneString(v.assertString()), v.Tag())
+	case ptrBytes:
+		if v.ext&bytesTagged != 0 {
+			return BytesWithTag(cloneBytes(v.assertBytes()), v.Tag())
+		}
+		return Bytes(cloneBytes(v.assertBytes()))
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string:
+		return String(cloneString(vf))
+	case *taggedString:
+		return StringWithTag(cloneString(vf.str), vf.tag)
+	case []byte:
+		return Bytes(cloneBytes(vf))
+	case *lazyBytes:
+		return Bytes(cloneBytes(vf.bytes()))
+	case *taggedBytes:
+		return BytesWithTag(cloneBytes(vf.bytes), vf.tag)
+	case *correlated:
+		return vf.v.Clone().WithCorrelation(vf.id)
+	case cloner:
+		return v.rebox(vf.Clone())
+	}
+	return v
+}
+
+// cloneBytes copies b, keeping whether an empty slice is nil.
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+func cloneString(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return string(append([]byte(nil), s...))
+}
+
+// DeepClone returns a copy of the value, like Clone, that also recursively
+// copies boxed maps, slices, and arrays, including any Value, map, or slice
+// nested inside of them. Mutating a nested container of the copy does not
+// affect the original. Pointers, structs, and other opaque values are
+// shallow copied. A container that refers back to itself is copied once,
+// with its copy referring back to the copy.
+func (v Value) DeepClone() Value {
+	return v.deepClone(make(map[deepKey]reflect.Value))
+}
+
+// deepKey identifies a map or slice that was already copied.
+type deepKey struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+var valueRType = reflect.TypeOf(Value{})
+
+func (v Value) deepClone(seen map[deepKey]reflect.Value) Value {
+	if v.isPrim() {
+		return v
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return v.Clone()
+	}
+	vf := v.assertNonPrimAny()
+	switch vf := vf.(type) {
+	case []byte:
+		return v.Clone()
+	case *correlated:
+		return vf.v.deepClone(seen).WithCorrelation(vf.id)
+	}
+	switch rv := reflect.ValueOf(vf); rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return v.rebox(deepCopy(rv, seen).Interface())
+	}
+	return v.Clone()
+}
+
+func deepCopy(rv reflect.Value,
+	seen map[deepKey]reflect.Value) reflect.Value {
+	if rv.Type() == valueRType {
+		v := rv.Interface().(Value).deepClone(seen)
+		return reflect.ValueOf(v)
+	}
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type()).Elem()
+		out.Set(deepCopy(rv.Elem(), seen))
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		key := deepKey{rv.Pointer(), 0, rv.Type()}
+		if out, ok := seen[key]; ok {
+			return out
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		seen[key] = out
+		iter := rv.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		key := deepKey{rv.Pointer(), rv.Len(), rv.Type()}
+		if out, ok := seen[key]; ok {
+			return out
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		seen[key] = out
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(deepCopy(rv.Index(i), seen))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(deepCopy(rv.Index(i), seen))
+		}
+		return out
+	default:
+		return rv
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"testing"
+	"unsafe"
+)
+
+type cloneable struct{ data []int }
+
+func (c *cloneable) Clone() any {
+	return &cloneable{append([]int(nil), c.data...)}
+}
+
+func TestClone(t *testing.T) {
+	b := []byte("hello")
+	v := Bytes(b)
+	c := v.Clone()
+	b[0] = 'j'
+	assert(v.String() == "jello")
+	assert(c.IsBytes() && c.String() == "hello")
+
+	s := "hello"
+	c = StringWithTag(s, 9).Clone()
+	assert(c.IsString() && c.String() == "hello" && c.Tag() == 9)
+	assert((*sface)(unsafe.Pointer(&s)).ptr != c.ptr)
+
+	assert(Int(5).Clone().Int() == 5)
+	assert(Float64(1.5).Clone().Float64() == 1.5)
+	assert(Nil().Clone().IsNil())
+
+	cl := &cloneable{[]int{1, 2}}
+	c = Any(cl).Clone()
+	cl.data[0] = 9
+	assert(c.Any().(*cloneable).data[0] == 1)
+
+	p := &Jello{1, 2}
+	assert(Any(p).Clone().Any().(*Jello) == p)
+
+	forceIfaceStrs = true
+	b = []byte("hello")
+	c = Bytes(b).Clone()
+	b[0] = 'j'
+	assert(c.IsBytes() && c.String() == "hello")
+	c = StringWithTag("hello", 9).Clone()
+	assert(c.IsString() && c.String() == "hello" && c.Tag() == 9)
+	forceIfaceStrs = false
+}
+
+func TestDeepClone(t *testing.T) {
+	inner := []int{1, 2, 3}
+	orig := map[string]any{
+		"list":   []any{inner, "a"},
+		"values": []Value{Bytes([]byte("hello")), Any([]int{4})},
+		"arr":    [2][]int{{5}, {6}},
+		"ptr":    &Jello{1, 2},
+		"nil":    nil,
+	}
+	v := Any(orig)
+	c := v.DeepClone()
+	m := c.Any().(map[string]any)
+
+	m["list"].([]any)[0].([]int)[0] = 100
+	assert(inner[0] == 1)
+	m["values"].([]Value)[0].Bytes()[0] = 'j'
+	assert(orig["values"].([]Value)[0].String() == "hello")
+	m["values"].([]Value)[1].Any().([]int)[0] = 100
+	assert(orig["values"].([]Value)[1].Any().([]int)[0] == 4)
+	arr := m["arr"].([2][]int)
+	arr[0][0] = 100
+	assert(orig["arr"].([2][]int)[0][0] == 5)
+	m["new"] = 1
+	assert(len(orig) == 5)
+	assert(m["ptr"].(*Jello) == orig["ptr"].(*Jello))
+	assert(m["nil"] == nil)
+
+	// cycles
+	cyc := make([]any, 2)
+	cyc[0] = 1
+	cyc[1] = cyc
+	cc := Any(cyc).DeepClone().Any().([]any)
+	cc[0] = 2
+	assert(cyc[0] == 1)
+	assert(cc[1].([]any)[0] == 2)
+	cm := map[string]any{}
+	cm["self"] = cm
+	ccm := Any(cm).DeepClone().Any().(map[string]any)
+	ccm["x"] = 1
+	assert(len(cm) == 1 && len(ccm["self"].(map[string]any)) == 2)
+
+	assert(Int(5).DeepClone().Int() == 5)
+	assert(String("hi").DeepClone().String() == "hi")
+	b := []byte("hello")
+	cb := Bytes(b).DeepClone()
+	b[0] = 'j'
+	assert(cb.IsBytes() && cb.String() == "hello")
+	p := &Jello{1, 2}
+	assert(Any(p).DeepClone().Any().(*Jello) == p)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// Values is a row of boxed values.
+type Values []Value
+
+// Coalesce returns the first value that is not nil, or Nil() when every
+// value is nil. Zero values such as Bool(false), Int(0), String(""), and
+// empty byte slices are not nil, and are returned.
+func Coalesce(vals ...Value) Value {
+	for _, v := range vals {
+		if !v.IsNil() {
+			return v
+		}
+	}
+	return Nil()
+}
+
+// Or returns the value, or other when the value is nil.
+func (v Value) Or(other Value) Value {
+	if v.IsNil() {
+		return other
+	}
+	return v
+}
+
+// CoalesceColumns merges rows into a single row, where each column is the
+// first non-nil value found in that column when scanning the rows from top
+// to bottom. The result is as long as the longest row. Columns missing from
+// a shorter row are treated as nil, and a column that is nil in every row
+// is Nil().
+func CoalesceColumns(rows []Values) Values {
+	var n int
+	for _, row := range rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	merged := make(Values, n)
+	for i := range merged {
+		for _, row := range rows {
+			if i < len(row) && !row[i].IsNil() {
+				merged[i] = row[i]
+				break
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "testing"
+
+func TestCoalesceColumns(t *testing.T) {
+	rows := []Values{
+		{Nil(), String("b1"), Nil()},
+		{Int(1), String("b2"), Nil(), Nil()},
+		{Int(2), Nil(), Bool(false)},
+	}
+	row := CoalesceColumns(rows)
+	assert(len(row) == 4)
+	assert(row[0].IsInt() && row[0].Int() == 1)
+	assert(row[1].String() == "b1")
+	assert(row[2].IsBool() && !row[2].Bool())
+	assert(row[3].IsNil())
+
+	assert(len(CoalesceColumns(nil)) == 0)
+	row = CoalesceColumns([]Values{{Int(0)}, {Int(1)}})
+	assert(len(row) == 1 && row[0].IsInt() && row[0].Int() == 0)
+}
+
+func TestCoalesce(t *testing.T) {
+	assert(Coalesce().IsNil())
+	assert(Coalesce(Nil(), Value{}).IsNil())
+	v := Coalesce(Value{}, Nil(), Int(2), Int(3))
+	assert(v.IsInt() && v.Int() == 2)
+
+	// zero values are present, not missing
+	v = Coalesce(Nil(), Bool(false), Bool(true))
+	assert(v.IsBool() && !v.Bool())
+	v = Coalesce(Nil(), Int(0), Int(1))
+	assert(v.IsInt() && v.Int() == 0)
+	v = Coalesce(Nil(), String(""), String("cfg"))
+	assert(v.IsString() && v.String() == "")
+	v = Coalesce(Nil(), StringWithTag("", 3), String("cfg"))
+	assert(v.IsString() && v.Tag() == 3)
+	v = Coalesce(Nil(), Bytes(nil), String("cfg"))
+	assert(v.IsBytes() && len(v.Bytes()) == 0)
+	v = Coalesce(Nil(), Bytes([]byte{}), String("cfg"))
+	assert(v.IsBytes() && len(v.Bytes()) == 0)
+	v = Coalesce(Nil(), Bytes(make([]byte, 0, 8)), String("cfg"))
+	assert(v.IsBytes() && len(v.Bytes()) == 0)
+	forceIfaceStrs = true
+	v = Coalesce(Nil(), String(""), String("cfg"))
+	assert(v.IsString() && v.String() == "")
+	forceIfaceStrs = false
+
+	assert(Nil().Or(Int(1)).Int() == 1)
+	assert(Value{}.Or(Int(1)).Int() == 1)
+	assert(Int(0).Or(Int(1)).Int() == 0)
+	assert(String("").Or(String("x")).String() == "")
+	assert(String("").Len() == 0 && !String("").IsNil())
+	assert(!Bytes(nil).IsNil() && Bytes(nil).IsZero())
+}
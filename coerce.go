@@ -0,0 +1,252 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"time"
+)
+
+// AnyAs boxes v converted to the kind k, such as AnyAs("5", KindInt) or
+// AnyAs(5.0, KindInt) becoming Int64(5). When k is KindAny, or v cannot be
+// converted to k without losing information, v is boxed using box.Any. Only
+// nil converts to KindNil.
+//
+// Strings and byte slices are parsed. Numbers convert between kinds only
+// when the number is exactly representable, such that 5.0 converts to an
+// int while 5.5 does not. Any value converts to KindString and KindBytes.
+func AnyAs(v any, k Kind) Value {
+	b := Any(v)
+	if x, ok := b.as(k); ok {
+		return x
+	}
+	return b
+}
+
+// as converts v to the kind k without losing information, and returns
+// false when it cannot.
+func (v Value) as(k Kind) (Value, bool) {
+	if v.Kind() == k {
+		return v, true
+	}
+	switch k {
+	case KindBool:
+		if x, ok := v.AsBool(); ok {
+			return Bool(x), true
+		}
+	case KindInt:
+		if x, ok := v.AsInt64(); ok {
+			return Int64(x), true
+		}
+	case KindUint:
+		if x, ok := v.AsUint64(); ok {
+			return Uint64(x), true
+		}
+	case KindFloat:
+		if x, ok := v.AsFloat64(); ok {
+			return Float64(x), true
+		}
+	case KindCustom:
+		if x, ok := v.AsUint64(); ok {
+			return CustomBits(x), true
+		}
+	case KindID:
+		if x, ok := v.AsUint64(); ok {
+			return ID(x), true
+		}
+	case KindString:
+		return String(v.String()), true
+	case KindBytes:
+		return Bytes(v.Bytes()), true
+	case KindTime:
+		if t := v.Time(); !t.IsZero() {
+			return Time(t), true
+		}
+	case KindPercent:
+		if x, ok := v.AsFloat64(); ok {
+			return Percent(x), true
+		}
+	case KindDuration:
+		if v.IsString() || v.IsBytes() {
+			if d, err := time.ParseDuration(v.String()); err == nil {
+				return Duration(d), true
+			}
+		} else if x, ok := v.AsInt64(); ok {
+			return Duration(time.Duration(x)), true
+		}
+	case KindRune:
+		if s, ok := v.StringOk(); ok {
+			if r := v.Rune(); len(s) > 0 && string(r) == s {
+				return Rune(r), true
+			}
+		} else if x, ok := v.AsInt64(); ok && x == int64(rune(x)) {
+			return Rune(rune(x)), true
+		}
+	case KindColor:
+		if v.IsString() || v.IsBytes() {
+			if c, err := ParseColor(v.String()); err == nil {
+				return c, true
+			}
+		}
+	case KindSemVer:
+		if v.IsString() || v.IsBytes() {
+			if sv, err := ParseSemVer(v.String()); err == nil {
+				return sv, true
+			}
+		}
+	}
+	return Value{}, false
+}
+
+// CoercionReport counts how the values convert to the kind k. A value is
+// ok when it converts without losing information, as with AnyAs and the
+// checked accessors, such as Float64(5) or String("5") to KindInt. It is
+// lossy when Convert still returns a value but loses information, such as
+// Float64(5.5) to KindInt becoming 5, and failed when Convert returns
+// Nil(), such as for a nil value or a string that does not parse.
+func (vs Values) CoercionReport(k Kind) (ok, lossy, failed int) {
+	for _, v := range vs {
+		if k == KindAny || v.Kind() == k {
+			ok++
+		} else if v.IsNil() {
+			failed++
+		} else if _, exact := v.as(k); exact {
+			ok++
+		} else if !v.Convert(k).IsNil() {
+			lossy++
+		} else {
+			failed++
+		}
+	}
+	return ok, lossy, failed
+}
+
+// AsInt64 returns the value as an int64, and false when the value is not
+// a number, or a string that parses as one, or when the number is not
+// exactly representable as an int64. Unlike Int64, it never returns a
+// silent zero, so a boxed 0 or "0" can be told apart from "hello".
+func (v Value) AsInt64() (int64, bool) {
+	v = v.unwrap()
+	switch v.ptr {
+	case int64Type, durationType, runeType:
+		return int64(v.ext), true
+	case boolType:
+		return int64(v.ext), true
+	case uint64Type, custBitsType, idType:
+		return int64(v.ext), v.ext <= math.MaxInt64
+	case float64Type, percentType:
+		return ftoiExact(math.Float64frombits(v.ext))
+	}
+	if s, ok := v.numericString(); ok {
+		if x, err := parseInt(s); err == nil {
+			return x, true
+		}
+		if f, err := parseFloat(s); err == nil {
+			return ftoiExact(f)
+		}
+	}
+	return 0, false
+}
+
+// AsUint64 is like AsInt64 for a uint64.
+func (v Value) AsUint64() (uint64, bool) {
+	v = v.unwrap()
+	switch v.ptr {
+	case uint64Type, custBitsType, idType, boolType:
+		return v.ext, true
+	case int64Type, durationType, runeType:
+		return v.ext, int64(v.ext) >= 0
+	case float64Type, percentType:
+		return ftouExact(math.Float64frombits(v.ext))
+	}
+	if s, ok := v.numericString(); ok {
+		if x, err := parseUint(s); err == nil {
+			return x, true
+		}
+		if f, err := parseFloat(s); err == nil {
+			return ftouExact(f)
+		}
+	}
+	return 0, false
+}
+
+// AsFloat64 returns the value as a float64, and false when the value is
+// not a number or a string that parses as one.
+func (v Value) AsFloat64() (float64, bool) {
+	v = v.unwrap()
+	switch v.ptr {
+	case float64Type, percentType:
+		return math.Float64frombits(v.ext), true
+	case int64Type, durationType, runeType:
+		return float64(int64(v.ext)), true
+	case uint64Type, custBitsType, idType, boolType:
+		return float64(v.ext), true
+	}
+	if s, ok := v.numericString(); ok {
+		if x, err := parseFloat(s); err == nil {
+			return x, true
+		}
+		return 0, false
+	}
+	if !v.isPrim() {
+		if vf, ok := v.assertNonPrimAny().(Float64er); ok {
+			return vf.Float64(), true
+		}
+	}
+	return 0, false
+}
+
+// AsBool returns the value as a bool, and false when the value is not a
+// bool, an int, a uint, a float other than NaN, or a string that parses
+// using strconv.ParseBool or the tokens set by SetBoolTokens.
+func (v Value) AsBool() (bool, bool) {
+	v = v.unwrap()
+	switch v.ptr {
+	case boolType, int64Type, uint64Type, custBitsType:
+		return v.ext != 0, true
+	case float64Type:
+		x := math.Float64frombits(v.ext)
+		return x != 0, !math.IsNaN(x)
+	}
+	if s, ok := v.numericString(); ok {
+		if x, err := parseBool(s); err == nil {
+			return x, true
+		}
+	}
+	return false, false
+}
+
+// AsString returns the value as a string, and false when the value is not
+// a string or a byte slice.
+func (v Value) AsString() (string, bool) {
+	v = v.unwrap()
+	return v.numericString()
+}
+
+// numericString returns the contents of a string or byte slice, which may
+// be parsed as a number.
+func (v Value) numericString() (string, bool) {
+	if s, ok := v.StringOk(); ok {
+		return s, true
+	}
+	if b, ok := v.BytesOk(); ok {
+		return string(b), true
+	}
+	return "", false
+}
+
+func ftoiExact(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+func ftouExact(f float64) (uint64, bool) {
+	if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, false
+	}
+	return uint64(f), true
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAnyAs(t *testing.T) {
+	v := AnyAs("5", KindInt)
+	assert(v.IsInt() && v.Int() == 5)
+	v = AnyAs(5.0, KindInt)
+	assert(v.IsInt() && v.Int() == 5)
+	v = AnyAs("5.0", KindInt)
+	assert(v.IsInt() && v.Int() == 5)
+	v = AnyAs([]byte("-5"), KindInt)
+	assert(v.IsInt() && v.Int() == -5)
+	v = AnyAs(uint64(5), KindInt)
+	assert(v.IsInt() && v.Int() == 5)
+	v = AnyAs(true, KindInt)
+	assert(v.IsInt() && v.Int() == 1)
+
+	// failed conversions fall back to Any
+	v = AnyAs(5.5, KindInt)
+	assert(v.IsFloat() && v.Float64() == 5.5)
+	v = AnyAs("five", KindInt)
+	assert(v.IsString() && v.String() == "five")
+	v = AnyAs(uint64(math.MaxUint64), KindInt)
+	assert(v.IsUint())
+	v = AnyAs(math.Inf(1), KindInt)
+	assert(v.IsFloat())
+	v = AnyAs(-1, KindUint)
+	assert(v.IsInt())
+	v = AnyAs(Jello{}, KindInt)
+	assert(v.Kind() == KindAny)
+
+	v = AnyAs("7", KindUint)
+	assert(v.IsUint() && v.Uint64() == 7)
+	v = AnyAs("18446744073709551615", KindUint)
+	assert(v.IsUint() && v.Uint64() == math.MaxUint64)
+	v = AnyAs("1.5", KindFloat)
+	assert(v.IsFloat() && v.Float64() == 1.5)
+	v = AnyAs(3, KindFloat)
+	assert(v.IsFloat() && v.Float64() == 3)
+	v = AnyAs("true", KindBool)
+	assert(v.IsBool() && v.Bool())
+	v = AnyAs(0, KindBool)
+	assert(v.IsBool() && !v.Bool())
+	v = AnyAs("yes", KindBool)
+	assert(v.IsString())
+	v = AnyAs(5, KindString)
+	assert(v.IsString() && v.String() == "5")
+	v = AnyAs("hi", KindBytes)
+	assert(v.IsBytes() && v.String() == "hi")
+	v = AnyAs("1m30s", KindDuration)
+	assert(v.IsDuration() && v.Duration() == 90*time.Second)
+	v = AnyAs(int64(time.Second), KindDuration)
+	assert(v.IsDuration() && v.Duration() == time.Second)
+	v = AnyAs("1970-01-01T00:00:01Z", KindTime)
+	assert(v.IsTime() && v.Time().Unix() == 1)
+	v = AnyAs("a", KindRune)
+	assert(v.IsRune() && v.Rune() == 'a')
+	v = AnyAs(97, KindRune)
+	assert(v.IsRune() && v.String() == "a")
+	v = AnyAs("ab", KindRune)
+	assert(v.IsString())
+	v = AnyAs("#ff0000", KindColor)
+	assert(v.IsColor() && v.String() == "#ff0000ff")
+	v = AnyAs("0.5", KindPercent)
+	assert(v.IsPercent() && v.String() == "50.0%")
+	v = AnyAs(7, KindCustom)
+	assert(v.IsCustomBits() && v.Uint64() == 7)
+	v = AnyAs(7, KindID)
+	assert(v.IsID() && v.ID() == 7)
+	v = AnyAs(7, KindNil)
+	assert(v.IsInt() && v.Int() == 7)
+	assert(AnyAs(nil, KindNil).IsNil())
+	v = AnyAs("5", KindAny)
+	assert(v.IsString())
+	v = AnyAs(5, KindInt)
+	assert(v.IsInt() && v.Int() == 5)
+}
+
+func TestCoercionReport(t *testing.T) {
+	vs := Values{
+		Int(1), Float64(2), String("3"), Uint64(4), Bool(true),
+		Float64(2.5), String("2.5"), Uint64(math.MaxUint64),
+		String("x"), Nil(), Any(struct{}{}),
+	}
+	ok, lossy, failed := vs.CoercionReport(KindInt)
+	assert(ok == 5 && lossy == 3 && failed == 3)
+	ok, lossy, failed = vs.CoercionReport(KindAny)
+	assert(ok == len(vs) && lossy == 0 && failed == 0)
+	ok, lossy, failed = Values(nil).CoercionReport(KindInt)
+	assert(ok == 0 && lossy == 0 && failed == 0)
+}
+
+func TestAsStrict(t *testing.T) {
+	x, ok := String("42").AsInt64()
+	assert(ok && x == 42)
+	x, ok = String("0").AsInt64()
+	assert(ok && x == 0)
+	_, ok = String("hello").AsInt64()
+	assert(!ok)
+	_, ok = String("4.5").AsInt64()
+	assert(!ok)
+	_, ok = Nil().AsInt64()
+	assert(!ok)
+	_, ok = Uint64(math.MaxUint64).AsInt64()
+	assert(!ok)
+	x, ok = Int64(math.MinInt64).AsInt64()
+	assert(ok && x == math.MinInt64)
+
+	u, ok := String("18446744073709551615").AsUint64()
+	assert(ok && u == math.MaxUint64)
+	_, ok = String("-1").AsUint64()
+	assert(!ok)
+	_, ok = Int(-1).AsUint64()
+	assert(!ok)
+
+	f, ok := String("4.5").AsFloat64()
+	assert(ok && f == 4.5)
+	f, ok = Bytes([]byte("1e3")).AsFloat64()
+	assert(ok && f == 1000)
+	_, ok = String("hello").AsFloat64()
+	assert(!ok)
+	f, ok = Int(3).AsFloat64()
+	assert(ok && f == 3)
+	_, ok = Any(Jello{}).AsFloat64()
+	assert(!ok)
+
+	b, ok := String("true").AsBool()
+	assert(ok && b)
+	b, ok = String("0").AsBool()
+	assert(ok && !b)
+	_, ok = String("hello").AsBool()
+	assert(!ok)
+	_, ok = Float64(math.NaN()).AsBool()
+	assert(!ok)
+
+	s, ok := String("hello").AsString()
+	assert(ok && s == "hello")
+	s, ok = Bytes([]byte("hi")).AsString()
+	assert(ok && s == "hi")
+	_, ok = Int(1).AsString()
+	assert(!ok)
+	forceIfaceStrs = true
+	s, ok = StringWithTag("hello", 1).AsString()
+	assert(ok && s == "hello")
+	forceIfaceStrs = false
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"errors"
+	"image/color"
+)
+
+// RGBA boxes a color made of red, green, blue, and alpha components.
+// The color is stored inline and does not allocate. String() renders it
+// as a hex color, such as RGBA(255, 0, 0, 255).String() == "#ff0000ff",
+// and Any() returns a color.RGBA.
+func RGBA(r, g, b, a uint8) Value {
+	return Value{uint64(r)<<24 | uint64(g)<<16 | uint64(b)<<8 | uint64(a),
+		colorType}
+}
+
+var errColorSyntax = errors.New("box: invalid color syntax")
+
+// ParseColor parses a hex color in the form #rgb, #rgba, #rrggbb, or
+// #rrggbbaa. The alpha is 0xff when it's not provided.
+func ParseColor(s string) (Value, error) {
+	if len(s) < 2 || s[0] != '#' {
+		return Value{}, errColorSyntax
+	}
+	var x uint64
+	for i := 1; i < len(s); i++ {
+		var d byte
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			d = c - '0'
+		case c >= 'a' && c <= 'f':
+			d = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			d = c - 'A' + 10
+		default:
+			return Value{}, errColorSyntax
+		}
+		x = x<<4 | uint64(d)
+	}
+	switch len(s) - 1 {
+	case 3:
+		x = x<<4 | 0xF
+		fallthrough
+	case 4:
+		// expand each digit, such that #f00f becomes #ff0000ff
+		var y uint64
+		for i := 12; i >= 0; i -= 4 {
+			d := (x >> uint(i)) & 0xF
+			y = y<<8 | d<<4 | d
+		}
+		x = y
+	case 6:
+		x = x<<8 | 0xFF
+	case 8:
+	default:
+		return Value{}, errColorSyntax
+	}
+	return Value{x, colorType}, nil
+}
+
+// RGBA returns the red, green, blue, and alpha components from a value
+// created by box.RGBA or box.ParseColor. A boxed color.Color, or a string
+// or byte slice holding a hex color, is also converted. Returns all zeros
+// for any other value.
+func (v Value) RGBA() (r, g, b, a uint8) {
+	v = v.unwrap()
+	var c color.RGBA
+	if v.ptr == colorType {
+		c = v.color()
+	} else if v.IsString() || v.IsBytes() {
+		if cv, err := ParseColor(v.String()); err == nil {
+			c = cv.color()
+		}
+	} else if !v.isPrim() {
+		if cv, ok := v.assertNonPrimAny().(color.Color); ok {
+			c = color.RGBAModel.Convert(cv).(color.RGBA)
+		}
+	}
+	return c.R, c.G, c.B, c.A
+}
+
+// IsColor returns true if the boxed value was created using box.RGBA or
+// box.ParseColor.
+func (v Value) IsColor() bool { return v.unwrap().ptr == colorType }
+
+func (v Value) color() color.RGBA {
+	return color.RGBA{uint8(v.ext >> 24), uint8(v.ext >> 16),
+		uint8(v.ext >> 8), uint8(v.ext)}
+}
+
+func appendColor(dst []byte, x uint64) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '#')
+	for i := 28; i >= 0; i -= 4 {
+		dst = append(dst, hex[(x>>uint(i))&0xF])
+	}
+	return dst
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	v := RGBA(0x12, 0x34, 0x56, 0x78)
+	assert(v.IsColor() && !v.IsUint())
+	r, g, b, a := v.RGBA()
+	assert(r == 0x12 && g == 0x34 && b == 0x56 && a == 0x78)
+	assert(v.String() == "#12345678")
+	assert(string(v.AppendString(nil)) == "#12345678")
+	assert(v.Any().(color.RGBA) == color.RGBA{0x12, 0x34, 0x56, 0x78})
+	assert(v.Kind() == KindColor)
+	assert(v.GoString() == "box.RGBA(0x12, 0x34, 0x56, 0x78)")
+	assert(RGBA(0, 0, 0, 0).String() == "#00000000")
+	assert(RGBA(0, 0, 0, 0).IsZero())
+
+	for _, s := range []string{"#ff0000ff", "#FF0000", "#f00", "#f00f"} {
+		v, err := ParseColor(s)
+		assert(err == nil && v.IsColor())
+		assert(v.String() == "#ff0000ff")
+	}
+	v, err := ParseColor("#1234")
+	assert(err == nil && v.String() == "#11223344")
+	for _, s := range []string{
+		"", "#", "ff0000", "#ff", "#ff00f", "#ff00000", "#gg0000",
+	} {
+		_, err := ParseColor(s)
+		assert(err != nil)
+	}
+
+	r, g, b, a = String("#00ff00").RGBA()
+	assert(r == 0 && g == 0xff && b == 0 && a == 0xff)
+	r, g, b, a = Any(color.Gray{0x80}).RGBA()
+	assert(r == 0x80 && g == 0x80 && b == 0x80 && a == 0xff)
+	r, g, b, a = Int(1).RGBA()
+	assert(r == 0 && g == 0 && b == 0 && a == 0)
+
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var v2 Value
+	assert(v2.UnmarshalBinary(data) == nil)
+	assert(v2.IsColor() && v2.String() == "#11223344")
+	data, err = v.MarshalJSON()
+	assert(err == nil && string(data) == `"#11223344"`)
+}
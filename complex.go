@@ -0,0 +1,61 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "strconv"
+
+// Complex128 boxes a complex128.
+// A complex128 does not fit inline and is boxed using the same path as
+// box.Any.
+func Complex128(c complex128) Value {
+	return toIface(c)
+}
+
+// Complex64 boxes a complex64
+func Complex64(c complex64) Value { return Complex128(complex128(c)) }
+
+// Complex128 returns the value as a complex128.
+// Numeric primitives become the real part, and strings and byte slices are
+// parsed using strconv.ParseComplex. Returns zero for values that cannot be
+// converted.
+func (v Value) Complex128() complex128 {
+	v = v.unwrap()
+	if v.isPrim() {
+		if v.IsNumber() || v.IsCustomBits() || v.IsBool() {
+			return complex(v.Float64(), 0)
+		}
+		return 0
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case complex128:
+		return vf
+	case string, []byte, *taggedString:
+		c, err := strconv.ParseComplex(v.String(), 128)
+		if err == nil {
+			return c
+		}
+	}
+	return 0
+}
+
+// Complex64 returns the value as a complex64
+func (v Value) Complex64() complex64 { return complex64(v.Complex128()) }
+
+// IsComplex returns true if the boxed value is a complex number.
+func (v Value) IsComplex() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return false
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return false
+	}
+	switch v.assertNonPrimAny().(type) {
+	case complex128, complex64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "testing"
+
+func TestComplex(t *testing.T) {
+	v := Complex128(1 + 2i)
+	assert(v.IsComplex())
+	assert(v.Complex128() == 1+2i)
+	assert(v.Complex64() == 1+2i)
+	assert(v.Any().(complex128) == 1+2i)
+	assert(v.String() == "(1+2i)")
+	assert(Complex64(3-4i).Complex128() == 3-4i)
+	assert(Any(complex64(3 - 4i)).IsComplex())
+	assert(Any(complex64(3-4i)).Any().(complex128) == 3-4i)
+	assert(Any(complex128(5i)).Complex128() == 5i)
+	forceIfacePtrs = true
+	assert(Complex128(1 + 2i).IsComplex())
+	assert(Complex128(1+2i).Complex128() == 1+2i)
+	forceIfacePtrs = false
+
+	assert(Float64(1.5).Complex128() == 1.5)
+	assert(Int(-2).Complex128() == -2)
+	assert(String("(1+2i)").Complex128() == 1+2i)
+	assert(Bytes([]byte("3i")).Complex128() == 3i)
+	assert(String("hello").Complex128() == 0)
+	assert(Nil().Complex128() == 0)
+	assert(!Float64(1).IsComplex())
+	assert(!String("1i").IsComplex())
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Slice boxes a list of values.
+// The slice is not copied.
+func Slice(vs []Value) Value {
+	return toIface(vs)
+}
+
+// Slice returns the list from a value created by box.Slice, or a boxed
+// []Value or Values. A value created by box.RunLength is expanded into a
+// new list. Returns false for any other value.
+func (v Value) Slice() ([]Value, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil, false
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case []Value:
+		return vf, true
+	case Values:
+		return vf, true
+	case *runLength:
+		vs := make([]Value, vf.count)
+		for i := range vs {
+			vs[i] = vf.v
+		}
+		return vs, true
+	}
+	return nil, false
+}
+
+// AnySlice boxes each element of vs using box.Any. Returns nil when vs is
+// nil, and an empty slice when vs is empty.
+func AnySlice(vs []any) []Value {
+	if vs == nil {
+		return nil
+	}
+	out := make([]Value, len(vs))
+	for i, v := range vs {
+		out[i] = Any(v)
+	}
+	return out
+}
+
+// AnyValues is the inverse of AnySlice, returning Any() of each element of
+// vs. Returns nil when vs is nil, and an empty slice when vs is empty.
+func AnyValues(vs []Value) []any {
+	if vs == nil {
+		return nil
+	}
+	out := make([]any, len(vs))
+	for i, v := range vs {
+		out[i] = v.Any()
+	}
+	return out
+}
+
+// runLength is a list of count copies of the same value.
+type runLength struct {
+	v     Value
+	count int
+}
+
+// RunLength boxes a list of count copies of v, without storing each copy.
+// The list is read like a value created by box.Slice, where Len() returns
+// count, Index(i) returns v, and Slice() expands it into a new list.
+// Panics if count is negative.
+func RunLength(v Value, count int) Value {
+	if count < 0 {
+		panic("box: negative run length")
+	}
+	return toIface(&runLength{v: v, count: count})
+}
+
+// Index returns the element at index i of a list from box.Slice or
+// box.RunLength, or of a boxed []Value, Values, slice, or array. Returns
+// Nil() when i is out of range or the value is not a list.
+func (v Value) Index(i int) Value {
+	v = v.unwrap()
+	if v.isPrim() || v.IsString() || v.IsBytes() || i < 0 {
+		return Nil()
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case []Value:
+		if i < len(vf) {
+			return vf[i]
+		}
+	case Values:
+		if i < len(vf) {
+			return vf[i]
+		}
+	case *runLength:
+		if i < vf.count {
+			return vf.v
+		}
+	default:
+		switch rv := reflect.ValueOf(vf); rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			if i < rv.Len() {
+				return boxOf(rv.Index(i).Interface())
+			}
+		}
+	}
+	return Nil()
+}
+
+// IsSlice returns true if the boxed value is a list of values.
+func (v Value) IsSlice() bool {
+	_, ok := v.Slice()
+	return ok
+}
+
+// valueMap is an ordered map of values.
+type valueMap struct {
+	keys []Value
+	vals []Value
+}
+
+func (m *valueMap) String() string {
+	var sb strings.Builder
+	sb.WriteString("map[")
+	for i := range m.keys {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(m.keys[i].String())
+		sb.WriteByte(':')
+		sb.WriteString(m.vals[i].String())
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// Map boxes an ordered map, where each key in keys is paired with the
+// value at the same index in vals. The order of the keys is kept and
+// duplicate keys are allowed. The slices are not copied.
+// Panics if keys and vals are not the same length.
+func Map(keys []Value, vals []Value) Value {
+	if len(keys) != len(vals) {
+		panic("box: mismatched map keys and values")
+	}
+	return toIface(&valueMap{keys: keys, vals: vals})
+}
+
+// Map returns the keys and values from a value created by box.Map.
+// Returns false for any other value.
+func (v Value) Map() (keys []Value, vals []Value, ok bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil, nil, false
+	}
+	m, ok := v.assertNonPrimAny().(*valueMap)
+	if !ok {
+		return nil, nil, false
+	}
+	return m.keys, m.vals, true
+}
+
+// IsMap returns true if the boxed value was created using box.Map.
+func (v Value) IsMap() bool {
+	_, _, ok := v.Map()
+	return ok
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	v := Slice([]Value{Int(1), String("a"), Slice([]Value{Bool(true)})})
+	assert(v.IsSlice() && !v.IsMap())
+	vs, ok := v.Slice()
+	assert(ok && len(vs) == 3)
+	assert(vs[0].Int() == 1 && vs[1].String() == "a")
+	inner, ok := vs[2].Slice()
+	assert(ok && len(inner) == 1 && inner[0].Bool())
+	assert(v.String() == "[1 a [true]]")
+	assert(v.Kind() == KindAny)
+
+	grid := Slice([]Value{
+		Slice([]Value{Int(1), Int(2)}),
+		Slice([]Value{Int(3), Slice([]Value{Int(4)})}),
+	})
+	rows, _ := grid.Slice()
+	row, _ := rows[1].Slice()
+	cell, ok := row[1].Slice()
+	assert(ok && cell[0].Int() == 4)
+
+	vs, ok = Slice(nil).Slice()
+	assert(ok && len(vs) == 0)
+	vs, ok = Any(Values{Int(1)}).Slice()
+	assert(ok && len(vs) == 1)
+	_, ok = Any([]int{1}).Slice()
+	assert(!ok)
+	_, ok = Int(1).Slice()
+	assert(!ok)
+	_, ok = String("[1]").Slice()
+	assert(!ok)
+	forceIfacePtrs = true
+	vs, ok = Slice([]Value{Int(1)}).Slice()
+	assert(ok && vs[0].Int() == 1)
+	forceIfacePtrs = false
+}
+
+func TestMap(t *testing.T) {
+	v := Map(
+		[]Value{String("b"), String("a"), Int(1)},
+		[]Value{Int(1), Slice([]Value{Int(2)}), Map(nil, nil)},
+	)
+	assert(v.IsMap() && !v.IsSlice())
+	keys, vals, ok := v.Map()
+	assert(ok && len(keys) == 3 && len(vals) == 3)
+	assert(keys[0].String() == "b" && keys[1].String() == "a")
+	assert(vals[0].Int() == 1)
+	assert(vals[1].IsSlice() && vals[2].IsMap())
+	assert(v.String() == "map[b:1 a:[2] 1:map[]]")
+	assert(v.Len() == 3 && v.Kind() == KindMap && KindMap.String() == "map")
+	assert(Map(nil, nil).Len() == 0)
+	data, err := json.Marshal(v)
+	assert(err == nil && string(data) == `[["b",1],["a",[2]],[1,{}]]`)
+	data, err = json.Marshal(Map(
+		[]Value{String("b"), String("a\""), String("b")},
+		[]Value{Int(1), Map(nil, nil), Nil()},
+	))
+	assert(err == nil && string(data) == `{"b":1,"a\"":{},"b":null}`)
+
+	_, _, ok = Int(1).Map()
+	assert(!ok)
+	_, _, ok = Any(map[string]any{}).Map()
+	assert(!ok)
+
+	defer func() { assert(recover() != nil) }()
+	Map([]Value{Int(1)}, nil)
+}
+
+func TestRunLength(t *testing.T) {
+	v := RunLength(Int(7), 3)
+	assert(v.Len() == 3 && v.IsSlice())
+	for i := 0; i < 3; i++ {
+		assert(v.Index(i).Int() == 7)
+	}
+	assert(v.Index(-1).IsNil() && v.Index(3).IsNil())
+	vs, ok := v.Slice()
+	assert(ok && len(vs) == 3)
+	for _, x := range vs {
+		assert(x.IsInt() && x.Int() == 7)
+	}
+	data, err := v.MarshalJSON()
+	assert(err == nil && string(data) == "[7,7,7]")
+	x, n, err := ReadMsgpack(v.AppendMsgpack(nil))
+	assert(err == nil && n > 0)
+	vs, ok = x.Slice()
+	assert(ok && len(vs) == 3 && vs[2].Int() == 7)
+
+	v = RunLength(String("a"), 0)
+	vs, ok = v.Slice()
+	assert(ok && len(vs) == 0 && v.Len() == 0 && v.Index(0).IsNil())
+
+	// a large run does not store each copy
+	v = RunLength(Float64(1.5), 1<<40)
+	assert(v.Len() == 1<<40 && v.Index(1<<40-1).Float64() == 1.5)
+
+	var panicked bool
+	func() {
+		defer func() { panicked = recover() != nil }()
+		RunLength(Nil(), -1)
+	}()
+	assert(panicked)
+}
+
+func TestIndex(t *testing.T) {
+	v := Slice([]Value{Int(1), String("b")})
+	assert(v.Index(0).Int() == 1 && v.Index(1).String() == "b")
+	assert(v.Index(2).IsNil() && v.Index(-1).IsNil())
+	assert(Any(Values{Int(3)}).Index(0).Int() == 3)
+	assert(Any([]int{4, 5}).Index(1).Int() == 5)
+	assert(Any([2]string{"x", "y"}).Index(1).String() == "y")
+	assert(Any([]Value{Int(6)}).Index(0).Int() == 6)
+	assert(Any([]any{Int(6)}).Index(0).Int() == 6)
+	assert(String("hello").Index(0).IsNil())
+	assert(Bytes([]byte("hi")).Index(0).IsNil())
+	assert(Int(1).Index(0).IsNil() && Nil().Index(0).IsNil())
+	assert(Any(map[string]int{"0": 1}).Index(0).IsNil())
+}
+
+func TestAnySlice(t *testing.T) {
+	assert(AnySlice(nil) == nil && AnyValues(nil) == nil)
+	vs := AnySlice([]any{})
+	assert(vs != nil && len(vs) == 0)
+	xs := AnyValues([]Value{})
+	assert(xs != nil && len(xs) == 0)
+
+	in := []any{nil, true, int64(-1), uint64(2), 1.5, "hi", []byte("b"),
+		Jello{1, 2}, &Jello{3, 4}}
+	vs = AnySlice(in)
+	assert(len(vs) == len(in))
+	assert(vs[0].IsNil() && vs[1].Bool() && vs[2].Int() == -1)
+	assert(vs[5].String() == "hi" && vs[6].IsBytes())
+	out := AnyValues(vs)
+	assert(reflect.DeepEqual(in, out))
+	assert(out[8].(*Jello) == in[8].(*Jello))
+	assert(AnyValues([]Value{Int(1), Nil()})[1] == nil)
+}
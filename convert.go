@@ -0,0 +1,186 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"time"
+)
+
+// ConversionError is returned by the E methods, such as Int64E, when a
+// value cannot be converted.
+type ConversionError struct {
+	From   Kind   // kind of the value being converted
+	To     string // name of the target type, such as "int64"
+	Reason string // why the conversion failed, such as "invalid syntax"
+}
+
+func (e ConversionError) Error() string {
+	return "box: cannot convert " + e.From.String() + " to " + e.To + ": " +
+		e.Reason
+}
+
+// Int64E is like AsInt64, but returns a ConversionError describing why the
+// value could not be converted, such as a string that does not parse, or a
+// float that is NaN, infinite, or has a fractional part. Does not allocate
+// when the conversion succeeds.
+func (v Value) Int64E() (int64, error) {
+	if x, ok := v.AsInt64(); ok {
+		return x, nil
+	}
+	return 0, v.conversionError("int64", true, false)
+}
+
+// Uint64E is like AsUint64, but returns a ConversionError describing why
+// the value could not be converted, such as a negative number.
+// Does not allocate when the conversion succeeds.
+func (v Value) Uint64E() (uint64, error) {
+	if x, ok := v.AsUint64(); ok {
+		return x, nil
+	}
+	return 0, v.conversionError("uint64", true, true)
+}
+
+// Float64E is like AsFloat64, but returns a ConversionError describing why
+// the value could not be converted. Does not allocate when the conversion
+// succeeds.
+func (v Value) Float64E() (float64, error) {
+	if x, ok := v.AsFloat64(); ok {
+		return x, nil
+	}
+	return 0, v.conversionError("float64", false, false)
+}
+
+// BoolE is like AsBool, but returns a ConversionError describing why the
+// value could not be converted. Does not allocate when the conversion
+// succeeds.
+func (v Value) BoolE() (bool, error) {
+	if x, ok := v.AsBool(); ok {
+		return x, nil
+	}
+	return false, v.conversionError("bool", false, false)
+}
+
+// StringE returns the value as a string, like String, and a
+// ConversionError when the value is nil.
+func (v Value) StringE() (string, error) {
+	if v.IsNil() {
+		return "", ConversionError{KindNil, "string", "value is nil"}
+	}
+	return v.String(), nil
+}
+
+// conversionError explains why a conversion to the named type failed.
+func (v Value) conversionError(to string, integer, unsigned bool,
+) ConversionError {
+	e := ConversionError{From: v.Kind(), To: to}
+	f, isNum := v.AsFloat64()
+	if _, ok := v.numericString(); ok {
+		// a string that parses as a float failed as an integer because of
+		// its value, which is explained below like any other float
+		isNum = isNum && integer
+	}
+	switch {
+	case v.IsNil():
+		e.Reason = "value is nil"
+	case isNum && math.IsNaN(f):
+		e.Reason = "value is NaN"
+	case !integer && (v.IsString() || v.IsBytes()):
+		e.Reason = "invalid syntax"
+	case !integer:
+		e.Reason = "not a " + to
+	case isNum && math.IsInf(f, 0):
+		e.Reason = "value is infinite"
+	case isNum && f != math.Trunc(f):
+		e.Reason = "value has a fractional part"
+	case isNum && unsigned && f < 0:
+		e.Reason = "value is negative"
+	case isNum:
+		e.Reason = "value out of range"
+	case v.IsString() || v.IsBytes():
+		e.Reason = "invalid syntax"
+	default:
+		e.Reason = "not a number"
+	}
+	return e
+}
+
+// Convert returns the value converted to the kind k, such as
+// String("42").Convert(KindInt) becoming Int64(42). Converting to
+// KindString returns String(v.String()), and converting to KindBytes
+// returns Bytes(v.Bytes()). Converting to KindAny, or to the kind the
+// value already has, returns the value unchanged.
+//
+// Conversions that are exact, as with AnyAs, are tried first. Otherwise
+// numbers, and strings that parse as numbers, are converted to the
+// numeric kinds the same way Int64, Uint64, Float64, and Bool do, which
+// may lose information:
+//
+//   - floats to KindInt, KindUint, KindCustom, KindID, and KindDuration
+//     are truncated toward zero, such that 3.9 becomes 3, and NaN and the
+//     infinities are handled as by Int64 and Uint64
+//   - negative numbers to unsigned kinds, and uints above math.MaxInt64
+//     to signed kinds, follow Uint64 and Int64
+//   - integers to KindFloat and KindPercent round when their magnitude is
+//     larger than 2^53
+//   - any number to KindBool becomes true when it's not zero
+//
+// Returns Nil() when the conversion is not possible, such as a Nil value,
+// a string that does not parse, or a value boxed with box.Any that has no
+// numeric form.
+func (v Value) Convert(k Kind) Value {
+	if k == KindAny {
+		return v
+	}
+	if v.IsNil() {
+		return Nil()
+	}
+	if x, ok := v.as(k); ok {
+		return x
+	}
+	if !v.isNumeric() {
+		return Nil()
+	}
+	switch k {
+	case KindBool:
+		if _, ok := v.numericString(); ok {
+			return Bool(v.Float64() != 0)
+		}
+		return Bool(v.Bool())
+	case KindInt:
+		return Int64(v.Int64())
+	case KindUint:
+		return Uint64(v.Uint64())
+	case KindFloat:
+		return Float64(v.Float64())
+	case KindCustom:
+		return CustomBits(v.Uint64())
+	case KindID:
+		return ID(v.Uint64())
+	case KindPercent:
+		return Percent(v.Float64())
+	case KindDuration:
+		return Duration(time.Duration(v.Int64()))
+	}
+	return Nil()
+}
+
+// isNumeric returns true when the value is a primitive, a string that
+// parses as a number, or a value boxed with box.Any that can be read as a
+// number.
+func (v Value) isNumeric() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return true
+	}
+	if _, ok := v.AsFloat64(); ok {
+		return true
+	}
+	switch v.assertNonPrimAny().(type) {
+	case Int64er, Uint64er, Booler:
+		return !v.IsString() && !v.IsBytes()
+	}
+	return false
+}
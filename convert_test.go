@@ -0,0 +1,151 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func reason(err error) string {
+	var e ConversionError
+	if !errors.As(err, &e) {
+		return ""
+	}
+	return e.Reason
+}
+
+func TestConversionErrors(t *testing.T) {
+	x, err := String("12").Int64E()
+	assert(err == nil && x == 12)
+	_, err = String("12abc").Int64E()
+	assert(reason(err) == "invalid syntax")
+	assert(err.Error() == "box: cannot convert string to int64: invalid syntax")
+	_, err = Float64(math.NaN()).Int64E()
+	assert(reason(err) == "value is NaN")
+	_, err = Float64(math.Inf(-1)).Int64E()
+	assert(reason(err) == "value is infinite")
+	_, err = String("Inf").Int64E()
+	assert(reason(err) == "value is infinite")
+	_, err = Float64(1.5).Int64E()
+	assert(reason(err) == "value has a fractional part")
+	_, err = String("1.5").Int64E()
+	assert(reason(err) == "value has a fractional part")
+	_, err = Uint64(math.MaxUint64).Int64E()
+	assert(reason(err) == "value out of range")
+	_, err = Nil().Int64E()
+	assert(reason(err) == "value is nil")
+	_, err = Any(Jello{}).Int64E()
+	assert(reason(err) == "not a number")
+	assert(err.Error() == "box: cannot convert any to int64: not a number")
+
+	u, err := String("7").Uint64E()
+	assert(err == nil && u == 7)
+	_, err = Int(-1).Uint64E()
+	assert(reason(err) == "value is negative")
+	_, err = String("-1").Uint64E()
+	assert(reason(err) == "value is negative")
+	_, err = Float64(-2).Uint64E()
+	assert(reason(err) == "value is negative")
+	var ce ConversionError
+	assert(errors.As(err, &ce) && ce.From == KindFloat && ce.To == "uint64")
+
+	f, err := String("1.5").Float64E()
+	assert(err == nil && f == 1.5)
+	_, err = String("abc").Float64E()
+	assert(reason(err) == "invalid syntax")
+	_, err = Bool(true).Float64E()
+	assert(err == nil)
+	_, err = Any(Jello{}).Float64E()
+	assert(reason(err) == "not a float64")
+
+	b, err := String("true").BoolE()
+	assert(err == nil && b)
+	_, err = String("yes").BoolE()
+	assert(reason(err) == "invalid syntax")
+	_, err = Float64(math.NaN()).BoolE()
+	assert(reason(err) == "value is NaN")
+	_, err = Duration(1).BoolE()
+	assert(reason(err) == "not a bool")
+
+	s, err := Int(5).StringE()
+	assert(err == nil && s == "5")
+	s, err = String("").StringE()
+	assert(err == nil && s == "")
+	_, err = Nil().StringE()
+	assert(reason(err) == "value is nil")
+
+	vals := []Value{Int(1), String("12"), Float64(2), Bool(true)}
+	assert(allocs(func() {
+		for _, v := range vals {
+			v.Int64E()
+			v.Uint64E()
+			v.Float64E()
+		}
+		String("true").BoolE()
+		Int(1).BoolE()
+		String("hello").StringE()
+	}) == 0)
+}
+
+func TestConvert(t *testing.T) {
+	check := func(v Value, k Kind, exp Value) {
+		t.Helper()
+		got := v.Convert(k)
+		if got.Kind() != exp.Kind() || got.String() != exp.String() {
+			t.Fatalf("%#v.Convert(%s) = %#v, want %#v", v, k, got, exp)
+		}
+	}
+	check(String("42"), KindInt, Int64(42))
+	check(String("3.9"), KindInt, Int64(3))
+	check(String("-3.9"), KindInt, Int64(-3))
+	check(Float64(3.9), KindInt, Int64(3))
+	check(Float64(-3.9), KindInt, Int64(-3))
+	check(Float64(math.NaN()), KindInt, Int64(0))
+	check(Float64(3.9), KindUint, Uint64(3))
+	check(Bytes([]byte("7.5")), KindUint, Uint64(7))
+	check(Int64(42), KindString, String("42"))
+	check(Float64(2.5), KindBytes, Bytes([]byte("2.5")))
+	check(Bool(true), KindInt, Int64(1))
+	check(Int64(5), KindFloat, Float64(5))
+	check(Int64(math.MaxInt64), KindFloat, Float64(math.MaxInt64))
+	check(String("1.5"), KindFloat, Float64(1.5))
+	check(Int64(2), KindBool, Bool(true))
+	check(Float64(0), KindBool, Bool(false))
+	check(String("42"), KindBool, Bool(true))
+	check(String("0.0"), KindBool, Bool(false))
+	check(String("true"), KindBool, Bool(true))
+	check(Float64(0.5), KindPercent, Percent(0.5))
+	check(Float64(1.5), KindDuration, Duration(1))
+	check(String("1s"), KindDuration, Duration(time.Second))
+	check(Float64(7.9), KindCustom, CustomBits(7))
+	check(Int64(0xab), KindID, ID(0xab))
+	check(String("#ff0000"), KindColor, RGBA(0xff, 0, 0, 0xff))
+	check(String("1.2.3"), KindSemVer, SemVer(1, 2, 3))
+	check(Int64(65), KindRune, Rune('A'))
+	check(Int64(1), KindNil, Nil())
+
+	// impossible
+	check(Nil(), KindInt, Nil())
+	check(Nil(), KindString, Nil())
+	check(String("hello"), KindInt, Nil())
+	check(String("hello"), KindFloat, Nil())
+	check(String("hello"), KindBool, Nil())
+	check(String("red"), KindColor, Nil())
+	check(Int64(1), KindColor, Nil())
+	check(Float64(1.5), KindRune, Nil())
+	check(Int64(1), KindTime, Nil())
+	check(Int64(1), KindEnumSet, Nil())
+	check(Any(struct{ X int }{1}), KindInt, Nil())
+
+	// unchanged
+	v := Any(struct{ X int }{1})
+	assert(v.Convert(KindAny).Any() == struct{ X int }{1})
+	assert(Int64(1).Convert(KindAny).IsInt())
+	v = StringWithTag("hi", 7).Convert(KindString)
+	assert(v.Tag() == 7)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// correlated is a value carrying a correlation id.
+type correlated struct {
+	v  Value
+	id uint64
+}
+
+func (c *correlated) String() string   { return c.v.String() }
+func (c *correlated) Float64() float64 { return c.v.Float64() }
+func (c *correlated) Int64() int64     { return c.v.Int64() }
+func (c *correlated) Uint64() uint64   { return c.v.Uint64() }
+func (c *correlated) Bool() bool       { return c.v.Bool() }
+
+func (c *correlated) MarshalJSON() ([]byte, error) {
+	return c.v.MarshalJSON()
+}
+
+// WithCorrelation returns the value wrapped with a correlation id, such as
+// a request id used for tracing. The wrapped value has the same kind, tag,
+// length, type, conversions, Is and Ok checks, and JSON, binary, and
+// msgpack encodings as v, and Any returns the same value as v.Any. Wrapping
+// a value that already has a correlation id replaces the id. The wrapper
+// allocates, and it is not Nil even when v is.
+func (v Value) WithCorrelation(id uint64) Value {
+	return toIface(&correlated{v: v.uncorrelated(), id: id})
+}
+
+// Correlation returns the id from a value created by WithCorrelation.
+// Returns false for any other value.
+func (v Value) Correlation() (uint64, bool) {
+	if c, ok := v.correlated(); ok {
+		return c.id, true
+	}
+	return 0, false
+}
+
+// uncorrelated returns the value without its correlation id.
+func (v Value) uncorrelated() Value {
+	if c, ok := v.correlated(); ok {
+		return c.v
+	}
+	return v
+}
+
+// unwrap returns the value that a wrapper stands in for, such as the value
+// from WithCorrelation without its id, or the wider value for a scalar from
+// AnyExact. Accessors that look at the layout of a value call it first so
+// that wrapped values behave like the originals.
+func (v Value) unwrap() Value {
+	v = v.uncorrelated()
+	if isExact(v.ptr) {
+		return v.widened()
+	}
+	return v
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCorrelation(t *testing.T) {
+	v := Int64(-42).WithCorrelation(7)
+	id, ok := v.Correlation()
+	assert(ok && id == 7)
+	assert(v.Int64() == -42 && v.Int() == -42 && v.Float64() == -42)
+	assert(v.Uint64() == 0 && v.Bool())
+	assert(v.String() == "-42" && string(v.Bytes()) == "-42")
+	assert(string(v.AppendString([]byte("x"))) == "x-42")
+	assert(v.Any().(int64) == -42 && v.Kind() == KindInt)
+	assert(v.TypeName() == "int64")
+	assert(v.ReflectValue().Int() == -42)
+	assert(fmt.Sprint(v) == "-42")
+	data, err := v.MarshalJSON()
+	assert(err == nil && string(data) == "-42")
+
+	v = String("3.5").WithCorrelation(8)
+	assert(v.String() == "3.5" && v.Float64() == 3.5 && v.Kind() == KindString)
+	v = Bytes([]byte("true")).WithCorrelation(8)
+	assert(string(v.Bytes()) == "true" && v.Bool() && v.Kind() == KindBytes)
+	v = Any(struct{ X int }{1}).WithCorrelation(9)
+	assert(v.Any() == struct{ X int }{1} && v.Kind() == KindAny)
+	data, err = v.MarshalJSON()
+	assert(err == nil && string(data) == `{"X":1}`)
+
+	// re-wrapping replaces the id
+	v = String("hi").WithCorrelation(1).WithCorrelation(2)
+	id, ok = v.Correlation()
+	assert(ok && id == 2 && v.String() == "hi")
+	c := v.assertNonPrimAny().(*correlated)
+	_, ok = c.v.Correlation()
+	assert(!ok)
+
+	for _, v := range []Value{Nil(), Int(1), String("hi"), Any(1.5)} {
+		_, ok := v.Correlation()
+		assert(!ok)
+	}
+	v = Nil().WithCorrelation(3)
+	assert(!v.IsNil() && v.Kind() == KindNil && v.Any() == nil)
+}
+
+func TestCorrelationAccessors(t *testing.T) {
+	v := Int(5).WithCorrelation(1)
+	assert(v.IsInt() && v.IsNumber() && !v.IsUint() && !v.IsString())
+	x, ok := v.AsInt64()
+	assert(ok && x == 5)
+	x, ok = v.Int64Ok()
+	assert(ok && x == 5)
+	n, ok := As[int](v)
+	assert(ok && n == 5)
+	num, ok := v.Number()
+	nx, _ := num.Int64()
+	assert(ok && nx == 5)
+	assert(v.Len() == -1 && !v.IsZero() && Int(0).WithCorrelation(1).IsZero())
+	assert(v.BigInt().Int64() == 5)
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var u Value
+	assert(u.UnmarshalBinary(data) == nil && u.EqualIdentity(Int(5)))
+	assert(hex.EncodeToString(v.AppendMsgpack(nil)) == "05")
+	assert(fmt.Sprintf("%#v", v) == "box.Int64(5).WithCorrelation(1)")
+
+	v = StringWithTag("hey", 3).WithCorrelation(2)
+	assert(v.IsString() && v.Tag() == 3 && v.Len() == 3)
+	s, ok := v.StringOk()
+	assert(ok && s == "hey")
+	assert(fmt.Sprintf("%#v", v) ==
+		`box.StringWithTag("hey", 3).WithCorrelation(2)`)
+	v = Bytes([]byte("hey")).WithCorrelation(2)
+	assert(v.IsBytes() && v.Len() == 3)
+
+	v = Duration(time.Second).WithCorrelation(3)
+	assert(v.IsDuration() && v.Duration() == time.Second)
+	d, ok := As[time.Duration](v)
+	assert(ok && d == time.Second)
+	v = Float64(1.5).WithCorrelation(4)
+	assert(v.IsFloat() && !v.IsNaN())
+	f, ok := v.Float64Ok()
+	assert(ok && f == 1.5)
+	v = Bool(true).WithCorrelation(5)
+	assert(v.IsBool())
+	b, ok := v.BoolOk()
+	assert(ok && b)
+
+	// sets, equality, and other paths that inspect the layout
+	v = EnumSet(1).WithCorrelation(5)
+	assert(v.HasEnum(1) && v.Union(EnumSet(2)).String() == "{1, 2}")
+	assert(v.Intersect(EnumSet(1)).String() == "{1}")
+	assert(EnumSet(2).Union(v).String() == "{1, 2}")
+	assert(Int(5).WithCorrelation(9).DeepEqual(Int(5)))
+	assert(Int(5).DeepEqual(Int(5).WithCorrelation(9)))
+	assert(!Int(5).WithCorrelation(9).DeepEqual(Int(6)))
+	assert(Any(struct{}{}).WithCorrelation(1).Convert(KindInt).IsNil())
+	assert(Any((*int)(nil)).WithCorrelation(1).IsNilPointer())
+	v = Bytes([]byte("hey")).WithCorrelation(6)
+	assert(fmt.Sprintf("%s|%v", v, v) == "hey|hey")
+	c := v.Clone()
+	id, ok := c.Correlation()
+	assert(ok && id == 6 && string(c.Bytes()) == "hey")
+	m := Any(map[string]any{"a": 1}).WithCorrelation(7)
+	assert(diffString(Diff(m, Any(map[string]any{"a": 2}))) == "replace a 1 2")
+	got, err := m.Apply([]Change{{Path: "a", Op: "replace", New: Int(2)}})
+	assert(err == nil && fmt.Sprint(got) == "map[a:2]")
+	c = m.DeepClone()
+	id, ok = c.Correlation()
+	assert(ok && id == 7 && c.DeepEqual(m))
+}
@@ -0,0 +1,415 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Change is a single difference reported by Diff.
+type Change struct {
+	Path string // escaped dotted path, such as "server.ports.1"
+	Op   string // "add", "remove", or "replace"
+	Old  Value  // the value in a, or Nil() for "add"
+	New  Value  // the value in b, or Nil() for "remove"
+}
+
+// Diff returns the changes that turn a into b.
+//
+// Maps with string keys, including values created by box.Map, and slices,
+// including values created by box.Slice, are compared recursively. A key
+// that is only in b is an "add", a key that is only in a is a "remove",
+// and the same holds for elements past the end of the shorter slice. Any
+// other values, or a map and a slice at the same path, are compared using
+// DeepEqual, and differ by a "replace". Paths are dotted, with slice
+// elements by index, such as "server.ports.1". The root path is "".
+// Within a key, "~" is written as "~0" and "." as "~1", as in a JSON
+// Pointer, and an empty key is written as "~2", such that the key "a.b"
+// is the path "a~1b" and is not mistaken for "b" inside "a".
+//
+// Keys are reported in the order of a value created by box.Map, and in
+// sorted order for a Go map. Elements removed from the end of a slice are
+// reported last first, so that applying the changes in order, as Apply
+// does, keeps each index valid. Returns nil when a and b are equal.
+func Diff(a, b Value) []Change {
+	var changes []Change
+	diffValues(&changes, "", a, b)
+	return changes
+}
+
+func diffValues(changes *[]Change, path string, a, b Value) {
+	join := func(key string) string {
+		if path == "" {
+			return escapePathKey(key)
+		}
+		return path + "." + escapePathKey(key)
+	}
+	if akeys, avals, ok := diffMap(a); ok {
+		if bkeys, bvals, ok := diffMap(b); ok {
+			bidx := make(map[string]int, len(bkeys))
+			for i, k := range bkeys {
+				bidx[k] = i
+			}
+			aidx := make(map[string]bool, len(akeys))
+			for i, k := range akeys {
+				aidx[k] = true
+				if j, ok := bidx[k]; ok {
+					diffValues(changes, join(k), avals[i], bvals[j])
+				} else {
+					*changes = append(*changes,
+						Change{join(k), "remove", avals[i], Nil()})
+				}
+			}
+			for i, k := range bkeys {
+				if !aidx[k] {
+					*changes = append(*changes,
+						Change{join(k), "add", Nil(), bvals[i]})
+				}
+			}
+			return
+		}
+	}
+	if aelems, ok := diffSlice(a); ok {
+		if belems, ok := diffSlice(b); ok {
+			for i := 0; i < len(aelems) && i < len(belems); i++ {
+				diffValues(changes, join(strconv.Itoa(i)), aelems[i],
+					belems[i])
+			}
+			for i := len(belems); i < len(aelems); i++ {
+				// removed from the end, so that the indexes stay valid
+				// when the changes are applied in order
+				i := len(aelems) - 1 - (i - len(belems))
+				*changes = append(*changes,
+					Change{join(strconv.Itoa(i)), "remove", aelems[i], Nil()})
+			}
+			for i := len(aelems); i < len(belems); i++ {
+				*changes = append(*changes,
+					Change{join(strconv.Itoa(i)), "add", Nil(), belems[i]})
+			}
+			return
+		}
+	}
+	if !a.DeepEqual(b) {
+		*changes = append(*changes, Change{path, "replace", a, b})
+	}
+}
+
+var pathKeyEscaper = strings.NewReplacer("~", "~0", ".", "~1")
+
+// escapePathKey returns the key as a segment of a change path.
+func escapePathKey(key string) string {
+	if key == "" {
+		return "~2"
+	}
+	return pathKeyEscaper.Replace(key)
+}
+
+// splitChangePath returns the unescaped keys of a change path, and false
+// when a segment is empty or has an unknown escape.
+func splitChangePath(p string) ([]string, bool) {
+	if p == "" {
+		return nil, true
+	}
+	segs := strings.Split(p, ".")
+	for i, seg := range segs {
+		if seg == "~2" {
+			segs[i] = ""
+			continue
+		}
+		if seg == "" {
+			return nil, false
+		}
+		if !strings.Contains(seg, "~") {
+			continue
+		}
+		var sb strings.Builder
+		for j := 0; j < len(seg); j++ {
+			if seg[j] != '~' {
+				sb.WriteByte(seg[j])
+				continue
+			}
+			if j+1 == len(seg) || seg[j+1] != '0' && seg[j+1] != '1' {
+				return nil, false
+			}
+			j++
+			if seg[j] == '0' {
+				sb.WriteByte('~')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		segs[i] = sb.String()
+	}
+	return segs, true
+}
+
+// diffMap returns the keys and values of a map with string keys.
+func diffMap(v Value) (keys []string, vals []Value, ok bool) {
+	v = v.unwrap()
+	if v.isPrim() || v.IsString() || v.IsBytes() {
+		return nil, nil, false
+	}
+	if mkeys, mvals, ok := v.Map(); ok {
+		keys = make([]string, len(mkeys))
+		for i := range mkeys {
+			keys[i] = mkeys[i].String()
+		}
+		return keys, mvals, true
+	}
+	rv := reflect.ValueOf(v.assertNonPrimAny())
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, nil, false
+	}
+	for _, k := range rv.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	vals = make([]Value, len(keys))
+	for i, k := range keys {
+		vals[i] = boxOf(rv.MapIndex(reflect.ValueOf(k).
+			Convert(rv.Type().Key())).Interface())
+	}
+	return keys, vals, true
+}
+
+// diffSlice returns the elements of a slice or array, other than a byte
+// slice.
+func diffSlice(v Value) ([]Value, bool) {
+	v = v.unwrap()
+	if v.isPrim() || v.IsString() || v.IsBytes() {
+		return nil, false
+	}
+	if elems, ok := v.Slice(); ok {
+		return elems, true
+	}
+	switch rv := reflect.ValueOf(v.assertNonPrimAny()); rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		elems := make([]Value, rv.Len())
+		for i := range elems {
+			elems[i] = boxOf(rv.Index(i).Interface())
+		}
+		return elems, true
+	}
+	return nil, false
+}
+
+// Apply returns a copy of the value with the changes applied in order, as
+// reported by Diff, such that a.Apply(Diff(a, b)) holds the same contents
+// as b. The value is not modified. Maps and slices along the path of a
+// change are copied, keeping their types, and the other parts are shared.
+//
+// An "add" sets a map key, or inserts into a slice at an index that is at
+// most its length. A "remove" deletes a map key or slice element, and a
+// "replace" sets an existing one. The root path "" replaces the whole
+// value. Paths are escaped as by Diff. Returns an error when a path does
+// not exist or is not escaped correctly, such as "a..b", when an op is not
+// known, or when a new value does not fit the element type of a Go map or
+// slice.
+func (v Value) Apply(changes []Change) (Value, error) {
+	for _, c := range changes {
+		segs, ok := splitChangePath(c.Path)
+		if !ok {
+			return Nil(), errors.New("box: invalid change path " +
+				strconv.Quote(c.Path))
+		}
+		var err error
+		v, err = applyChange(v, segs, c)
+		if err != nil {
+			return Nil(), err
+		}
+	}
+	return v, nil
+}
+
+func applyChange(v Value, segs []string, c Change) (Value, error) {
+	switch c.Op {
+	case "add", "remove", "replace":
+	default:
+		return Nil(), errors.New("box: unknown change op " +
+			strconv.Quote(c.Op))
+	}
+	if len(segs) == 0 {
+		if c.Op == "remove" {
+			return Nil(), nil
+		}
+		return c.New, nil
+	}
+	notFound := func() (Value, error) {
+		return Nil(), errors.New("box: change path not found " +
+			strconv.Quote(c.Path))
+	}
+	v = v.unwrap()
+	key, last := segs[0], len(segs) == 1
+	if keys, vals, ok := v.Map(); ok {
+		i := 0
+		for i < len(keys) && keys[i].String() != key {
+			i++
+		}
+		if i == len(keys) && !(last && c.Op == "add") {
+			return notFound()
+		}
+		keys = append([]Value(nil), keys...)
+		vals = append([]Value(nil), vals...)
+		switch {
+		case i == len(keys):
+			keys = append(keys, String(key))
+			vals = append(vals, c.New)
+		case last && c.Op == "remove":
+			keys = append(keys[:i], keys[i+1:]...)
+			vals = append(vals[:i], vals[i+1:]...)
+		default:
+			x, err := applyChange(vals[i], segs[1:], c)
+			if err != nil {
+				return Nil(), err
+			}
+			vals[i] = x
+		}
+		return Map(keys, vals), nil
+	}
+	if elems, ok := v.Slice(); ok {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i > len(elems) ||
+			(i == len(elems) && !(last && c.Op == "add")) {
+			return notFound()
+		}
+		elems = append([]Value(nil), elems...)
+		switch {
+		case last && c.Op == "add":
+			elems = append(elems[:i], append([]Value{c.New}, elems[i:]...)...)
+		case last && c.Op == "remove":
+			elems = append(elems[:i], elems[i+1:]...)
+		default:
+			x, err := applyChange(elems[i], segs[1:], c)
+			if err != nil {
+				return Nil(), err
+			}
+			elems[i] = x
+		}
+		return Slice(elems), nil
+	}
+	if v.isPrim() || v.IsString() || v.IsBytes() {
+		return notFound()
+	}
+	rv := reflect.ValueOf(v.assertNonPrimAny())
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return notFound()
+		}
+		k := reflect.ValueOf(key).Convert(rv.Type().Key())
+		old := rv.MapIndex(k)
+		if !old.IsValid() && !(last && c.Op == "add") {
+			return notFound()
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(iter.Key(), iter.Value())
+		}
+		if last && c.Op == "remove" {
+			m.SetMapIndex(k, reflect.Value{})
+			return Any(m.Interface()), nil
+		}
+		x := c.New
+		if !last {
+			var err error
+			x, err = applyChange(boxOf(old.Interface()), segs[1:], c)
+			if err != nil {
+				return Nil(), err
+			}
+		}
+		elem, err := reflectAs(x, rv.Type().Elem())
+		if err != nil {
+			return Nil(), err
+		}
+		m.SetMapIndex(k, elem)
+		return Any(m.Interface()), nil
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		n := rv.Len()
+		if err != nil || i < 0 || i > n ||
+			(i == n && !(last && c.Op == "add")) {
+			return notFound()
+		}
+		t := rv.Type()
+		if rv.Kind() == reflect.Array {
+			if last && c.Op != "replace" {
+				return Nil(), errors.New("box: cannot resize array at " +
+					strconv.Quote(c.Path))
+			}
+			s := reflect.New(t).Elem()
+			reflect.Copy(s, rv)
+			rv = s.Slice(0, n)
+		} else {
+			s := reflect.MakeSlice(t, n, n+1)
+			reflect.Copy(s, rv)
+			rv = s
+		}
+		switch {
+		case last && c.Op == "remove":
+			reflect.Copy(rv.Slice(i, n), rv.Slice(i+1, n))
+			rv = rv.Slice(0, n-1)
+		case last && c.Op == "add":
+			elem, err := reflectAs(c.New, t.Elem())
+			if err != nil {
+				return Nil(), err
+			}
+			rv = rv.Slice(0, n+1)
+			reflect.Copy(rv.Slice(i+1, n+1), rv.Slice(i, n))
+			rv.Index(i).Set(elem)
+		default:
+			x := c.New
+			if !last {
+				x, err = applyChange(boxOf(rv.Index(i).Interface()),
+					segs[1:], c)
+				if err != nil {
+					return Nil(), err
+				}
+			}
+			elem, err := reflectAs(x, t.Elem())
+			if err != nil {
+				return Nil(), err
+			}
+			rv.Index(i).Set(elem)
+		}
+		if t.Kind() == reflect.Array {
+			arr := reflect.New(t).Elem()
+			reflect.Copy(arr, rv)
+			return Any(arr.Interface()), nil
+		}
+		return Any(rv.Interface()), nil
+	}
+	return notFound()
+}
+
+// reflectAs returns the value as a reflect.Value that can be stored in an
+// element of type t.
+func reflectAs(v Value, t reflect.Type) (reflect.Value, error) {
+	if t == valueRType {
+		return reflect.ValueOf(v), nil
+	}
+	x := v.Any()
+	if x == nil {
+		return reflect.Zero(t), nil
+	}
+	rv := reflect.ValueOf(x)
+	if rv.Type().AssignableTo(t) {
+		return rv, nil
+	}
+	if isNumberKind(rv.Kind()) && isNumberKind(t.Kind()) ||
+		rv.Kind() == t.Kind() && rv.Type().ConvertibleTo(t) {
+		return rv.Convert(t), nil
+	}
+	return reflect.Value{}, errors.New("box: cannot use " +
+		rv.Type().String() + " as " + t.String())
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func diffString(changes []Change) string {
+	str := func(v Value) string {
+		if v.IsNil() {
+			return "nil"
+		}
+		return v.String()
+	}
+	var lines []string
+	for _, c := range changes {
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", c.Op, c.Path,
+			str(c.Old), str(c.New)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDiff(t *testing.T) {
+	a := Any(map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host":  "localhost",
+			"port":  8080,
+			"ports": []any{80, 443},
+			"tls":   true,
+		},
+		"debug": false,
+	})
+	b := Any(map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host":  "example.com",
+			"port":  "8080",
+			"ports": []any{80, 8443, 9000},
+		},
+		"workers": 4,
+	})
+	exp := strings.Join([]string{
+		"remove debug false nil",
+		"replace server.host localhost example.com",
+		"replace server.port 8080 8080",
+		"replace server.ports.1 443 8443",
+		"add server.ports.2 nil 9000",
+		"remove server.tls true nil",
+		"add workers nil 4",
+	}, "\n")
+	if got := diffString(Diff(a, b)); got != exp {
+		t.Fatalf("expected:\n%s\ngot:\n%s", exp, got)
+	}
+	changes := Diff(a, b)
+	assert(changes[2].Old.IsInt() && changes[2].New.IsString())
+
+	// reversed
+	exp = strings.Join([]string{
+		"replace server.host example.com localhost",
+		"replace server.port 8080 8080",
+		"replace server.ports.1 8443 443",
+		"remove server.ports.2 9000 nil",
+		"add server.tls nil true",
+		"remove workers 4 nil",
+		"add debug nil false",
+	}, "\n")
+	if got := diffString(Diff(b, a)); got != exp {
+		t.Fatalf("expected:\n%s\ngot:\n%s", exp, got)
+	}
+
+	assert(Diff(a, a) == nil)
+	assert(Diff(Int(1), Int(1)) == nil)
+	assert(diffString(Diff(Int(1), String("1"))) == "replace  1 1")
+
+	// box.Map keeps its order, and a map replaced by a slice is a replace
+	m1 := Map([]Value{String("z"), String("a")},
+		[]Value{Int(1), Slice([]Value{Int(1)})})
+	m2 := Map([]Value{String("z"), String("a")},
+		[]Value{Int(2), Any(map[string]int{"x": 1})})
+	assert(diffString(Diff(m1, m2)) ==
+		"replace z 1 2\nreplace a [1] map[x:1]")
+
+	// nested values and typed maps
+	v1 := Any(map[string]Value{"a": Any([]int{1, 2})})
+	v2 := Any(map[string]Value{"a": Any([]int{1})})
+	assert(diffString(Diff(v1, v2)) == "remove a.1 2 nil")
+	assert(Diff(Bytes([]byte("ab")), Bytes([]byte("ab"))) == nil)
+	assert(diffString(Diff(Bytes([]byte("ab")), Bytes([]byte("ac")))) ==
+		"replace  ab ac")
+
+	// keys are escaped, so that they are not mistaken for other paths
+	v1 = Any(map[string]any{"a.b": 1, "": 2, "~": map[string]int{"x.": 3}})
+	v2 = Any(map[string]any{"a.b": 4, "": 5, "~": map[string]int{"x.": 6}})
+	assert(diffString(Diff(v1, v2)) ==
+		"replace ~2 2 5\nreplace a~1b 1 4\nreplace ~0.x~1 3 6")
+}
+
+func TestApply(t *testing.T) {
+	pairs := [][2]Value{
+		{
+			Any(map[string]any{
+				"name": "app", "debug": false,
+				"server": map[string]any{
+					"host": "localhost", "port": 8080,
+					"ports": []any{80, 443, 8000, 9000}, "tls": true,
+				},
+			}),
+			Any(map[string]any{
+				"name": "app", "workers": 4,
+				"server": map[string]any{
+					"host": "example.com", "port": "8080",
+					"ports": []any{80, 8443}, "extra": []int{1},
+				},
+			}),
+		},
+		{Any([]int{1, 2, 3}), Any([]int{1, 5})},
+		{Any([]int{1}), Any([]int{1, 2, 3})},
+		{Any([]string{"a", "b"}), Any([]string{"b", "a", "c"})},
+		{Any([2]int{1, 2}), Any([2]int{1, 3})},
+		{Any(map[string]int{"a": 1, "b": 2}), Any(map[string]int{"b": 3})},
+		{
+			Any(map[string]Value{"a": Int(1), "b": Slice([]Value{Int(1)})}),
+			Any(map[string]Value{"b": Slice([]Value{Int(2), String("x")})}),
+		},
+		{
+			Map([]Value{String("z"), String("a")},
+				[]Value{Int(1), Slice([]Value{Int(1), Int(2)})}),
+			Map([]Value{String("a"), String("y")},
+				[]Value{Slice([]Value{Int(1)}), Bool(true)}),
+		},
+		{Int(1), String("one")},
+		{Any([]any{1, "x"}), Any(map[string]any{"0": 1})},
+		{
+			Any(map[string]any{"a.b": 1, "": 2, "a": map[string]any{"b": 3}}),
+			Any(map[string]any{"a.b": 4, "": "x", "~1": 5}),
+		},
+		{
+			Map([]Value{String(""), String("a.b")}, []Value{Int(1), Int(2)}),
+			Map([]Value{String("a.b"), String("~")}, []Value{Int(3), Int(4)}),
+		},
+	}
+	for i, p := range pairs {
+		a, b := p[0], p[1]
+		before := fmt.Sprint(a)
+		got, err := a.Apply(Diff(a, b))
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if d := Diff(got, b); d != nil {
+			t.Fatalf("%d: expected no diff, got:\n%s", i, diffString(d))
+		}
+		assert(fmt.Sprint(a) == before)
+		back, err := got.Apply(Diff(got, a))
+		assert(err == nil && Diff(back, a) == nil)
+	}
+
+	// types are kept
+	got, err := Any([]int{1, 2, 3}).Apply(Diff(Any([]int{1, 2, 3}),
+		Any([]int{1, 5})))
+	assert(err == nil && got.DeepEqual(Any([]int{1, 5})))
+	got, err = Any([2]int{1, 2}).Apply([]Change{
+		{Path: "1", Op: "replace", New: Int(7)},
+	})
+	assert(err == nil && got.Any().([2]int) == [2]int{1, 7})
+
+	// errors
+	v := Any(map[string]any{"a": []any{1, 2}, "s": "str"})
+	for _, c := range []Change{
+		{Path: "b", Op: "remove"},
+		{Path: "b", Op: "replace", New: Int(1)},
+		{Path: "b.c", Op: "add", New: Int(1)},
+		{Path: "a.2", Op: "remove"},
+		{Path: "a.2", Op: "replace", New: Int(1)},
+		{Path: "a.3", Op: "add", New: Int(1)},
+		{Path: "a.x", Op: "add", New: Int(1)},
+		{Path: "a.-1", Op: "add", New: Int(1)},
+		{Path: "s.x", Op: "add", New: Int(1)},
+		{Path: "a", Op: "move", New: Int(1)},
+		{Path: "a..0", Op: "replace", New: Int(1)},
+		{Path: ".a", Op: "replace", New: Int(1)},
+		{Path: "a.", Op: "replace", New: Int(1)},
+		{Path: ".", Op: "replace", New: Int(1)},
+		{Path: "s~", Op: "replace", New: Int(1)},
+		{Path: "s~3", Op: "replace", New: Int(1)},
+		{Path: "a~2", Op: "replace", New: Int(1)},
+	} {
+		_, err := v.Apply([]Change{c})
+		assert(err != nil)
+	}
+	_, err = Any([]int{1}).Apply([]Change{
+		{Path: "0", Op: "replace", New: String("x")},
+	})
+	assert(err != nil)
+	_, err = Any([1]int{1}).Apply([]Change{{Path: "1", Op: "add"}})
+	assert(err != nil)
+
+	got, err = v.Apply([]Change{{Path: "a.2", Op: "add", New: Int(3)},
+		{Path: "a.0", Op: "add", New: Int(0)}})
+	assert(err == nil && fmt.Sprint(got.Any().(map[string]any)["a"]) ==
+		"[0 1 2 3]")
+	got, err = v.Apply([]Change{{Op: "remove"}})
+	assert(err == nil && got.IsNil())
+	got, err = v.Apply(nil)
+	assert(err == nil && got.DeepEqual(v))
+
+	// escaped keys
+	v = Any(map[string]any{"a.b": 1, "": 2, "a": map[string]any{"b": 3}})
+	got, err = v.Apply([]Change{{Path: "a~1b", Op: "replace", New: Int(4)},
+		{Path: "~2", Op: "remove"}})
+	assert(err == nil && fmt.Sprint(got) == "map[a:map[b:3] a.b:4]")
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+// enumSet is a set of enum codes that do not all fit in a 64-bit mask.
+// The codes are sorted and unique.
+type enumSet struct {
+	codes []uint64
+}
+
+func (s *enumSet) String() string {
+	return string(appendEnumSet(nil, s.codes))
+}
+
+// EnumSet boxes a set of enum codes, such as a set of permission flags.
+// Duplicate codes are ignored. When every code is less than 64 the set is
+// stored inline as a bitmask and does not allocate. Otherwise the codes are
+// stored as a sorted list. String() renders the codes in ascending order,
+// such as EnumSet(3, 1).String() == "{1, 3}".
+func EnumSet(codes ...uint64) Value {
+	var mask uint64
+	for _, code := range codes {
+		if code >= 64 {
+			return enumSetOf(codes)
+		}
+		mask |= 1 << code
+	}
+	return Value{mask, enumSetType}
+}
+
+func enumSetOf(codes []uint64) Value {
+	sorted := append([]uint64(nil), codes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := 0
+	for i, code := range sorted {
+		if i == 0 || code != sorted[n-1] {
+			sorted[n] = code
+			n++
+		}
+	}
+	return toIface(&enumSet{sorted[:n]})
+}
+
+// IsEnumSet returns true if the boxed value was created using box.EnumSet.
+func (v Value) IsEnumSet() bool {
+	v = v.unwrap()
+	if v.ptr == enumSetType {
+		return true
+	}
+	if v.isPrim() {
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(*enumSet)
+	return ok
+}
+
+// EnumCodes returns the codes in the set, in ascending order.
+// Returns nil when the value is not an enum set.
+func (v Value) EnumCodes() []uint64 {
+	v = v.unwrap()
+	if v.ptr == enumSetType {
+		return maskCodes(v.ext)
+	}
+	if v.isPrim() {
+		return nil
+	}
+	if s, ok := v.assertNonPrimAny().(*enumSet); ok {
+		return append([]uint64(nil), s.codes...)
+	}
+	return nil
+}
+
+// HasEnum returns true if the set contains code.
+// Returns false when the value is not an enum set.
+func (v Value) HasEnum(code uint64) bool {
+	v = v.unwrap()
+	if v.ptr == enumSetType {
+		return code < 64 && v.ext&(1<<code) != 0
+	}
+	if v.isPrim() {
+		return false
+	}
+	if s, ok := v.assertNonPrimAny().(*enumSet); ok {
+		i := sort.Search(len(s.codes), func(i int) bool {
+			return s.codes[i] >= code
+		})
+		return i < len(s.codes) && s.codes[i] == code
+	}
+	return false
+}
+
+// Union returns a set of the codes that are in either v or o.
+// A value that is not an enum set is treated as the empty set.
+func (v Value) Union(o Value) Value {
+	m1, ok1 := v.enumMask()
+	m2, ok2 := o.enumMask()
+	if ok1 && ok2 {
+		return Value{m1 | m2, enumSetType}
+	}
+	return EnumSet(append(v.EnumCodes(), o.EnumCodes()...)...)
+}
+
+// Intersect returns a set of the codes that are in both v and o.
+// A value that is not an enum set is treated as the empty set.
+func (v Value) Intersect(o Value) Value {
+	m1, ok1 := v.enumMask()
+	m2, ok2 := o.enumMask()
+	if ok1 && ok2 {
+		return Value{m1 & m2, enumSetType}
+	}
+	var codes []uint64
+	for _, code := range v.EnumCodes() {
+		if o.HasEnum(code) {
+			codes = append(codes, code)
+		}
+	}
+	return EnumSet(codes...)
+}
+
+// enumMask returns the set as a bitmask, and false when the value is an
+// enum set that does not fit in a bitmask. Values that are not enum sets
+// are the empty mask.
+func (v Value) enumMask() (uint64, bool) {
+	v = v.unwrap()
+	if v.ptr == enumSetType {
+		return v.ext, true
+	}
+	if v.isPrim() {
+		return 0, true
+	}
+	_, ok := v.assertNonPrimAny().(*enumSet)
+	return 0, !ok
+}
+
+func maskCodes(mask uint64) []uint64 {
+	codes := make([]uint64, 0, bits.OnesCount64(mask))
+	for mask != 0 {
+		code := bits.TrailingZeros64(mask)
+		codes = append(codes, uint64(code))
+		mask &^= 1 << code
+	}
+	return codes
+}
+
+func appendEnumSet(dst []byte, codes []uint64) []byte {
+	dst = append(dst, '{')
+	for i, code := range codes {
+		if i > 0 {
+			dst = append(dst, ", "...)
+		}
+		dst = strconv.AppendUint(dst, code, 10)
+	}
+	return append(dst, '}')
+}
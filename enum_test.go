@@ -0,0 +1,76 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnumSet(t *testing.T) {
+	const (
+		read  = 0
+		write = 1
+		exec  = 2
+		admin = 100
+	)
+	rw := EnumSet(read, write)
+	assert(rw.ptr == enumSetType && rw.IsEnumSet())
+	assert(rw.Kind() == KindEnumSet)
+	assert(rw.HasEnum(read) && rw.HasEnum(write) && !rw.HasEnum(exec))
+	assert(!rw.HasEnum(admin))
+	assert(rw.String() == "{0, 1}")
+	assert(allocs(func() { rw = EnumSet(read, write) }) == 0)
+
+	wx := EnumSet(exec, write, write)
+	assert(wx.String() == "{1, 2}")
+	assert(rw.Union(wx).String() == "{0, 1, 2}")
+	assert(rw.Intersect(wx).String() == "{1}")
+	assert(rw.Intersect(EnumSet(exec)).String() == "{}")
+	assert(EnumSet().IsEnumSet() && EnumSet().String() == "{}")
+
+	// large codes are stored as a sorted list
+	big := EnumSet(admin, write, admin)
+	assert(big.ptr != enumSetType && big.IsEnumSet())
+	assert(big.Kind() == KindEnumSet)
+	assert(big.HasEnum(admin) && big.HasEnum(write) && !big.HasEnum(read))
+	assert(big.String() == "{1, 100}")
+	assert(reflect.DeepEqual(big.EnumCodes(), []uint64{1, 100}))
+	all := rw.Union(big)
+	assert(all.String() == "{0, 1, 100}")
+	assert(big.Union(rw).String() == "{0, 1, 100}")
+	v := big.Intersect(rw)
+	assert(v.ptr == enumSetType && v.String() == "{1}")
+	assert(rw.Intersect(big).String() == "{1}")
+	assert(all.Intersect(EnumSet(admin, 200)).String() == "{100}")
+
+	// other values are the empty set
+	assert(!Int(1).IsEnumSet() && !Int(1).HasEnum(0))
+	assert(Int(1).EnumCodes() == nil && String("x").EnumCodes() == nil)
+	assert(rw.Union(Nil()).String() == "{0, 1}")
+	assert(big.Union(String("x")).String() == "{1, 100}")
+	assert(rw.Intersect(Int(3)).String() == "{}")
+
+	for _, v := range []Value{rw, big, EnumSet()} {
+		data, err := v.MarshalBinary()
+		assert(err == nil)
+		var v2 Value
+		assert(v2.UnmarshalBinary(data) == nil)
+		assert(v2.IsEnumSet() && v2.String() == v.String())
+		for i := 0; i < len(data); i++ {
+			assert(v2.UnmarshalBinary(data[:i]) != nil)
+		}
+	}
+	data, _ := json.Marshal(big)
+	assert(string(data) == "[1,100]")
+	data, _ = json.Marshal(rw)
+	assert(string(data) == "[0,1]")
+	assert(rw.GoString() == "box.EnumSet(0, 1)")
+	assert(big.GoString() == "box.EnumSet(1, 100)")
+	assert(rw.Type() == big.Type())
+	dv, err := big.Value()
+	assert(err == nil && dv == "{1, 100}")
+}
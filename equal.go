@@ -0,0 +1,108 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// ifaceWords returns the type and data words of a non-primitive value that
+// was boxed through the interface path.
+func (v Value) ifaceWords() (typ uintptr, data unsafe.Pointer) {
+	if v.ext&0xFF == ptrIfacePtr {
+		words := (*[2]unsafe.Pointer)(v.ptr)
+		return uintptr(words[0]), words[1]
+	}
+	if tag := v.ext & 0xFF; tag == ptrFloat64s || tag == ptrInt64s {
+		// the slice header is inline, so the whole ext word identifies it
+		return uintptr(v.ext), v.ptr
+	}
+	return uintptr(v.ext >> 8), v.ptr
+}
+
+// EqualIdentity returns true if the two values are the same.
+// Primitives are compared by value, and strings and byte slices are compared
+// by their contents and tag. Any other value boxed with box.Any is only
+// equal to a value holding the same interface, that is, the same pointer
+// for pointer types or the same boxing of a non-pointer type. The contents
+// of those values are never inspected, which makes this a cheap check for
+// the same object even when the values are large or not comparable.
+func (v Value) EqualIdentity(o Value) bool {
+	if v.isPrim() || o.isPrim() {
+		if v.ptr != o.ptr {
+			return false
+		}
+		if v.ptr == float64Type || v.ptr == percentType ||
+			v.ptr == float32Type {
+			return math.Float64frombits(v.ext) == math.Float64frombits(o.ext)
+		}
+		return v.ext == o.ext
+	}
+	if v.IsString() || o.IsString() {
+		return v.IsString() && o.IsString() && v.Tag() == o.Tag() &&
+			v.String() == o.String()
+	}
+	if v.IsBytes() || o.IsBytes() {
+		return v.IsBytes() && o.IsBytes() && v.Tag() == o.Tag() &&
+			bytes.Equal(v.Bytes(), o.Bytes())
+	}
+	vtyp, vdata := v.ifaceWords()
+	otyp, odata := o.ifaceWords()
+	return vtyp == otyp && vdata == odata
+}
+
+// DeepEqual returns true if the two values hold equal contents.
+//
+// Primitives, strings, and byte slices are compared inline, like
+// EqualIdentity, and never use reflection. Times are compared using ==
+// on time.Time, whether or not they are stored inline. Lists from
+// box.Slice and maps from box.Map are compared element by element using
+// DeepEqual. Any other value boxed with box.Any is compared using
+// reflect.DeepEqual, which makes it much slower than EqualIdentity, but
+// also works for slices, maps, and other values that are not comparable
+// with ==. A Value nested inside such a value, as in a map[string]Value, is
+// compared by reflect.DeepEqual as a plain struct. Correlation ids from
+// WithCorrelation are ignored.
+func (v Value) DeepEqual(o Value) bool {
+	v, o = v.uncorrelated(), o.uncorrelated()
+	if v.EqualIdentity(o) {
+		return true
+	}
+	if v.isPrim() || o.isPrim() || v.IsString() || o.IsString() ||
+		v.IsBytes() || o.IsBytes() {
+		// EqualIdentity already compared the contents, except for times
+		// that are inline on one side only.
+		return v.IsTime() && o.IsTime() && v.Time() == o.Time()
+	}
+	if vs, ok := v.Slice(); ok {
+		os, ok := o.Slice()
+		if !ok || len(vs) != len(os) {
+			return false
+		}
+		for i := range vs {
+			if !vs[i].DeepEqual(os[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if vkeys, vvals, ok := v.Map(); ok {
+		okeys, ovals, ok := o.Map()
+		if !ok || len(vkeys) != len(okeys) {
+			return false
+		}
+		for i := range vkeys {
+			if !vkeys[i].DeepEqual(okeys[i]) ||
+				!vvals[i].DeepEqual(ovals[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(v.assertNonPrimAny(), o.assertNonPrimAny())
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEqualIdentity(t *testing.T) {
+	assert(Nil().EqualIdentity(Nil()))
+	assert(Int(1).EqualIdentity(Int(1)))
+	assert(!Int(1).EqualIdentity(Int(2)))
+	assert(!Int(1).EqualIdentity(Uint(1)))
+	assert(Float64(0).EqualIdentity(Float64(math.Copysign(0, -1))))
+	assert(!Float64(math.NaN()).EqualIdentity(Float64(math.NaN())))
+	assert(!Nil().EqualIdentity(Int(0)))
+	assert(String("hello").EqualIdentity(String("hello")))
+	assert(!String("hello").EqualIdentity(String("jello")))
+	assert(!String("hello").EqualIdentity(StringWithTag("hello", 1)))
+	assert(!String("hello").EqualIdentity(Bytes([]byte("hello"))))
+	assert(Bytes([]byte("hello")).EqualIdentity(Bytes([]byte("hello"))))
+	forceIfaceStrs = true
+	long := String("hello")
+	forceIfaceStrs = false
+	assert(long.EqualIdentity(String("hello")))
+	assert(String("hello").EqualIdentity(long))
+
+	p := &Jello{1, 2}
+	assert(Any(p).EqualIdentity(Any(p)))
+	assert(!Any(p).EqualIdentity(Any(&Jello{1, 2})))
+	js := []Jello{{1, 2}}
+	j := js[0]
+	assert(!Any(j).EqualIdentity(Any(j)))
+	v := Any(j)
+	assert(v.EqualIdentity(v))
+	assert(!v.EqualIdentity(Int(1)))
+	forceIfacePtrs = true
+	assert(Any(p).EqualIdentity(Any(p)))
+	assert(!Any(p).EqualIdentity(Any(&Jello{1, 2})))
+	assert(!Any(j).EqualIdentity(Any(j)))
+	v = Any(j)
+	assert(v.EqualIdentity(v))
+	forceIfacePtrs = false
+	assert(Any(p).EqualIdentity(v) == false)
+}
+
+func TestDeepEqual(t *testing.T) {
+	assert(Nil().DeepEqual(Nil()))
+	assert(Int(1).DeepEqual(Int(1)) && !Int(1).DeepEqual(Int(2)))
+	assert(!Int(1).DeepEqual(Uint(1)))
+	assert(!Float64(math.NaN()).DeepEqual(Float64(math.NaN())))
+	assert(String("a").DeepEqual(String(string([]byte("a")))))
+	assert(!String("a").DeepEqual(Bytes([]byte("a"))))
+	assert(Bytes([]byte("a")).DeepEqual(Bytes([]byte("a"))))
+	tm := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert(Time(tm).DeepEqual(Any(tm)))
+	assert(!Time(tm).DeepEqual(Time(tm.Add(1))))
+
+	// containers
+	a := Any([]int{1, 2, 3})
+	b := Any([]int{1, 2, 3})
+	assert(!a.EqualIdentity(b) && a.DeepEqual(b))
+	assert(!a.DeepEqual(Any([]int{1, 2})))
+	assert(Any(map[string]any{"a": []int{1}}).DeepEqual(
+		Any(map[string]any{"a": []int{1}})))
+	assert(!Any(map[string]any{"a": 1}).DeepEqual(Any(map[string]int{"a": 1})))
+	assert(Any(Jello{1, 2}).DeepEqual(Any(Jello{1, 2})))
+	assert(Any(&Jello{1, 2}).DeepEqual(Any(&Jello{1, 2})))
+	assert(Float64Slice([]float64{1}).DeepEqual(Any([]float64{1})))
+	assert(!a.DeepEqual(Int(1)) && !Int(1).DeepEqual(a))
+
+	s1 := Slice([]Value{String("x"), Any([]int{1})})
+	s2 := Slice([]Value{String(string([]byte("x"))), Any([]int{1})})
+	assert(s1.DeepEqual(s2))
+	assert(!s1.DeepEqual(Slice([]Value{String("x")})))
+	assert(!s1.DeepEqual(Slice([]Value{String("x"), Any([]int{2})})))
+	m1 := Map([]Value{String("k")}, []Value{s1})
+	m2 := Map([]Value{String("k")}, []Value{s2})
+	assert(m1.DeepEqual(m2))
+	assert(!m1.DeepEqual(Map([]Value{String("j")}, []Value{s1})))
+	assert(!m1.DeepEqual(s1))
+
+	prims := []Value{Int(1), Float64(2), String("abc"), Bytes([]byte("x"))}
+	assert(allocs(func() {
+		for _, v := range prims {
+			v.DeepEqual(v)
+		}
+	}) == 0)
+}
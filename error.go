@@ -0,0 +1,37 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// Error boxes an error.
+// A nil error is boxed as Nil().
+func Error(err error) Value {
+	if err == nil {
+		return Nil()
+	}
+	return toIface(err)
+}
+
+// Error returns the boxed error.
+// Returns nil when the value does not hold an error.
+func (v Value) Error() error {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return nil
+	}
+	err, _ := v.assertNonPrimAny().(error)
+	return err
+}
+
+// IsError returns true if the boxed value is an error.
+func (v Value) IsError() bool { return v.Error() != nil }
+
+// Unwrap returns the boxed error, or nil when the value does not hold an
+// error. A Value is not itself an error, so use errors.Is(v.Unwrap(), target)
+// to check the boxed error chain.
+func (v Value) Unwrap() error { return v.Error() }
@@ -0,0 +1,49 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type testError struct{ code int }
+
+func (e testError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestError(t *testing.T) {
+	v := Error(io.EOF)
+	assert(v.IsError())
+	assert(v.Error() == io.EOF)
+	assert(v.String() == "EOF")
+
+	wrapped := fmt.Errorf("read: %w", io.EOF)
+	v = Error(wrapped)
+	assert(v.Error() == wrapped)
+	assert(errors.Is(v.Unwrap(), io.EOF))
+
+	v = Any(testError{7})
+	assert(v.IsError())
+	var te testError
+	assert(errors.As(v.Unwrap(), &te) && te.code == 7)
+
+	assert(Error(nil).IsNil())
+	assert(!Nil().IsError() && Nil().Error() == nil)
+	assert(!Int(1).IsError() && Int(1).Unwrap() == nil)
+	assert(!String("EOF").IsError())
+	assert(!Any(Jello{}).IsError())
+
+	data, err := json.Marshal(Error(errors.New("x")))
+	assert(err == nil && string(data) == `"x"`)
+	data, err = json.Marshal(Any(testError{7}))
+	assert(err == nil && string(data) == `"code 7"`)
+
+	forceIfacePtrs = true
+	assert(Error(io.EOF).Error() == io.EOF)
+	forceIfacePtrs = false
+}
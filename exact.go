@@ -0,0 +1,84 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"unsafe"
+)
+
+// AnyExact boxes anything, like box.Any, except that Any returns the value
+// with exactly the type that went in. Any stores the scalar types int, int8,
+// int16, int32, uint, uint8, uint16, uint32, uintptr, float32, and complex64
+// as the wider int64, uint64, float64, or complex128, such that
+// Any(int32(7)).Any() is an int64. AnyExact instead boxes those as
+// primitives that record the exact type in place of the type of the wider
+// value, and all other values exactly as box.Any does. Like box.Any, boxing
+// a scalar does not allocate.
+//
+// A scalar from AnyExact has the kind, conversions, and Is and Ok checks of
+// the wider value, such that AnyExact(int32(7)).Kind() is KindInt, its
+// IsInt() is true, and As[int32] returns 7. Any, Type, and GoString report
+// the exact type, String formats a float32 with the fewest digits that read
+// back as the same float32, such that AnyExact(float32(0.1)) is "0.1", and
+// the value is not equal to the wider value by EqualIdentity or DeepEqual.
+// The JSON, msgpack, and binary encodings write the wider value, so the
+// exact type does not come back when decoded.
+func AnyExact(v any) Value {
+	switch x := v.(type) {
+	case int:
+		return Value{ext: uint64(x), ptr: intType}
+	case int8:
+		return Value{ext: uint64(x), ptr: int8Type}
+	case int16:
+		return Value{ext: uint64(x), ptr: int16Type}
+	case int32:
+		return Value{ext: uint64(x), ptr: int32Type}
+	case uint:
+		return Value{ext: uint64(x), ptr: uintType}
+	case uint8:
+		return Value{ext: uint64(x), ptr: uint8Type}
+	case uint16:
+		return Value{ext: uint64(x), ptr: uint16Type}
+	case uint32:
+		return Value{ext: uint64(x), ptr: uint32Type}
+	case uintptr:
+		return Value{ext: uint64(x), ptr: uintptrType}
+	case float32:
+		return Value{ext: math.Float64bits(float64(x)), ptr: float32Type}
+	case complex64:
+		return Value{ext: uint64(math.Float32bits(real(x)))<<32 |
+			uint64(math.Float32bits(imag(x))), ptr: complex64Type}
+	}
+	return Any(v)
+}
+
+// isExact returns true for the primitive types of scalars from AnyExact.
+func isExact(ptr unsafe.Pointer) bool {
+	return uintptr(ptr) >= uintptr(intType) &&
+		uintptr(ptr) <= uintptr(complex64Type)
+}
+
+// widened returns the value that box.Any gives for a scalar from AnyExact,
+// and the value itself for any other value. Integers and floats keep the
+// same ext, which already holds the wider value.
+func (v Value) widened() Value {
+	switch v.ptr {
+	case intType, int8Type, int16Type, int32Type:
+		return Value{ext: v.ext, ptr: int64Type}
+	case uintType, uint8Type, uint16Type, uint32Type, uintptrType:
+		return Value{ext: v.ext, ptr: uint64Type}
+	case float32Type:
+		return Value{ext: v.ext, ptr: float64Type}
+	case complex64Type:
+		return Complex64(v.complex64())
+	}
+	return v
+}
+
+func (v Value) complex64() complex64 {
+	return complex(math.Float32frombits(uint32(v.ext>>32)),
+		math.Float32frombits(uint32(v.ext)))
+}
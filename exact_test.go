@@ -0,0 +1,148 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnyExact(t *testing.T) {
+	for _, x := range []any{
+		true, int(-7), int8(-7), int16(-7), int32(-7), int64(-7),
+		uint(7), uint8(7), uint16(7), uint32(7), uint64(7), uintptr(7),
+		float32(-7), float64(-7), complex64(-7), complex128(-7),
+		int(-700000), uint32(700000), float32(1.5), "-7", []byte("-7"),
+		time.Duration(-7), time.Unix(7, 0), Jello{Neat: 7},
+	} {
+		v := AnyExact(x)
+		if reflect.TypeOf(v.Any()) != reflect.TypeOf(x) {
+			t.Fatalf("%T: got %T", x, v.Any())
+		}
+		assert(reflect.DeepEqual(v.Any(), x))
+		w := Any(x)
+		assert(v.Kind() == w.Kind())
+		assert(v.Int64() == w.Int64() && v.Uint64() == w.Uint64())
+		assert(v.Bool() == w.Bool() && v.Truthy() == w.Truthy())
+		assert(v.Complex128() == w.Complex128())
+		f := v.Float64()
+		assert(f == w.Float64() || f != f)
+		i, ok := v.AsInt64()
+		j, ok2 := w.AsInt64()
+		assert(i == j && ok == ok2)
+		u, ok := v.AsUint64()
+		u2, ok2 := w.AsUint64()
+		assert(u == u2 && ok == ok2)
+		f, ok = v.AsFloat64()
+		f2, ok2 := w.AsFloat64()
+		assert((f == f2 || f != f) && ok == ok2)
+		b, ok := v.AsBool()
+		b2, ok2 := w.AsBool()
+		assert(b == b2 && ok == ok2)
+		assert(v.IsInt() == w.IsInt() && v.IsUint() == w.IsUint())
+		assert(v.IsFloat() == w.IsFloat() && v.IsNumber() == w.IsNumber())
+		assert(v.IsComplex() == w.IsComplex() && v.IsZero() == w.IsZero())
+		i, ok = v.Int64Ok()
+		j, ok2 = w.Int64Ok()
+		assert(i == j && ok == ok2)
+		u, ok = v.Uint64Ok()
+		u2, ok2 = w.Uint64Ok()
+		assert(u == u2 && ok == ok2)
+		f, ok = v.Float64Ok()
+		f2, ok2 = w.Float64Ok()
+		assert(f == f2 && ok == ok2)
+		n, ok := v.Number()
+		n2, ok2 := w.Number()
+		assert(n == n2 && ok == ok2)
+		bi, bi2 := v.BigInt(), w.BigInt()
+		assert((bi == nil) == (bi2 == nil))
+		assert(bi == nil || bi.Cmp(bi2) == 0)
+	}
+	v := AnyExact(int32(7))
+	assert(v.Kind() == KindInt && v.Int64() == 7 && v.String() == "7")
+	assert(v.Type() == reflect.TypeOf(int32(0)))
+	assert(!v.DeepEqual(Int(7)) && v.DeepEqual(AnyExact(int32(7))))
+	v = AnyExact(float32(0.1))
+	assert(v.Kind() == KindFloat && v.Any().(float32) == 0.1)
+	assert(v.String() == "0.1" && v.Float32() == 0.1)
+	assert(AnyExact(complex64(1i)).IsComplex())
+	assert(!AnyExact(int8(0)).Truthy() && AnyExact(uint16(3)).Truthy())
+	assert(AnyExact(nil) == Nil() && AnyExact(int64(5)) == Int(5))
+	negZero := float32(math.Copysign(0, -1))
+	assert(AnyExact(float32(0)).EqualIdentity(AnyExact(negZero)))
+	assert(!AnyExact(int32(7)).EqualIdentity(AnyExact(int64(7))))
+	assert(!AnyExact(int32(7)).EqualIdentity(AnyExact(uint32(7))))
+
+	// scalars are primitives, which do not allocate
+	assert(AnyExact(int32(1 << 20)).Layout().Prim)
+	assert(AnyExact(complex64(1 + 2i)).Layout().Prim)
+	var x any = int32(1 << 20)
+	var f any = float32(1.5)
+	assert(allocs(func() { v = AnyExact(x) }) == 0)
+	assert(allocs(func() { v = AnyExact(f) }) == 0)
+	assert(allocs(func() { _ = v.Float64() + v.Float64() }) == 0)
+	assert(allocs(func() { _, _ = v.Float64Ok() }) == 0)
+	assert(v.Valid() && AnyExact(int8(-3)).Valid())
+	assert(!Value{ext: 300, ptr: uint8Type}.Valid())
+	assert(AnyExact(complex64(1+2i)).String() == "(1+2i)")
+	assert(fmt.Sprint(AnyExact(float32(0.1))) == "0.1")
+	assert(AnyExact(complex64(1+2i)).Any() == complex64(1+2i))
+	assert(!AnyExact(float32(math.NaN())).Truthy())
+}
+
+func testAnyExactAs[T comparable](x T) {
+	v := AnyExact(x)
+	y, ok := As[T](v)
+	assert(ok && y == x)
+	y, ok = As[T](v.WithCorrelation(1))
+	assert(ok && y == x)
+}
+
+func TestAnyExactAs(t *testing.T) {
+	testAnyExactAs(int(-7))
+	testAnyExactAs(int8(-7))
+	testAnyExactAs(int16(-7))
+	testAnyExactAs(int32(-7))
+	testAnyExactAs(uint(7))
+	testAnyExactAs(uint8(7))
+	testAnyExactAs(uint16(7))
+	testAnyExactAs(uint32(7))
+	testAnyExactAs(uintptr(7))
+	testAnyExactAs(float32(1.5))
+	testAnyExactAs(complex64(1 + 2i))
+	v := AnyExact(int32(7))
+	assert(v.IsInt() && v.IsNumber() && v.BigInt().Int64() == 7)
+	x, ok := v.Int64Ok()
+	assert(ok && x == 7)
+	n, ok := v.Number()
+	n2, _ := Int(7).Number()
+	assert(ok && n == n2)
+	assert(AnyExact(float32(1.5)).IsFloat())
+}
+
+func TestAnyExactEncoding(t *testing.T) {
+	for _, c := range []struct {
+		x any
+		w Value
+	}{
+		{int32(-7), Int(-7)}, {uint8(200), Uint(200)},
+		{float32(1.5), Float64(1.5)}, {complex64(2i), Complex128(2i)},
+	} {
+		v := AnyExact(c.x)
+		j1, err1 := v.MarshalJSON()
+		j2, err2 := c.w.MarshalJSON()
+		assert(string(j1) == string(j2) && err1 == nil && err2 == nil ||
+			err1 != nil && err2 != nil)
+		assert(string(v.AppendMsgpack(nil)) == string(c.w.AppendMsgpack(nil)))
+		b1, err1 := v.MarshalBinary()
+		b2, err2 := c.w.MarshalBinary()
+		assert(string(b1) == string(b2) && (err1 == nil) == (err2 == nil))
+	}
+	assert(AnyExact(int32(7)).GoString() == "box.AnyExact(int32(7))")
+	assert(AnyExact(float32(1.5)).GoString() == "box.AnyExact(float32(1.5))")
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+)
+
+// maxExactInt is the largest magnitude below which every integer is exactly
+// representable as a float64.
+const maxExactInt = 1 << 53
+
+// FitsInt64 returns true if the value converts to an int64 without loss,
+// which is when AsInt64 succeeds. This includes uints up to math.MaxInt64,
+// floats with integral values in range, and strings that parse as such.
+// Does not allocate for primitives.
+func (v Value) FitsInt64() bool {
+	_, ok := v.AsInt64()
+	return ok
+}
+
+// FitsUint64 returns true if the value converts to a uint64 without loss,
+// which is when AsUint64 succeeds.
+func (v Value) FitsUint64() bool {
+	_, ok := v.AsUint64()
+	return ok
+}
+
+// FitsInt32 returns true if the value converts to an int32 without loss.
+func (v Value) FitsInt32() bool {
+	x, ok := v.AsInt64()
+	return ok && x >= math.MinInt32 && x <= math.MaxInt32
+}
+
+// FitsInt returns true if the value converts to an int without loss, using
+// the word size of the platform.
+func (v Value) FitsInt() bool {
+	if strconv.IntSize == 32 {
+		return v.FitsInt32()
+	}
+	return v.FitsInt64()
+}
+
+// FitsFloat64Exactly returns true if the value converts to a float64
+// without loss. Floats always fit. Integers fit when their magnitude is at
+// most 2^53, and integers beyond that return false, even those that happen
+// to be representable, since their neighbors are not.
+func (v Value) FitsFloat64Exactly() bool {
+	n, ok := v.Number()
+	if !ok {
+		_, ok := v.AsFloat64()
+		return ok
+	}
+	switch n.kind {
+	case 'i':
+		x := int64(n.bits)
+		return x >= -maxExactInt && x <= maxExactInt
+	case 'u':
+		return n.bits <= maxExactInt
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestFits(t *testing.T) {
+	type fits struct {
+		i64, u64, i32, f64 bool
+	}
+	tests := []struct {
+		v    Value
+		want fits
+	}{
+		{Int(0), fits{true, true, true, true}},
+		{Int(-1), fits{true, false, true, true}},
+		{Int(math.MaxInt32), fits{true, true, true, true}},
+		{Int(math.MaxInt32 + 1), fits{true, true, false, true}},
+		{Int(math.MinInt32), fits{true, false, true, true}},
+		{Int(math.MinInt32 - 1), fits{true, false, false, true}},
+		{Int(1 << 53), fits{true, true, false, true}},
+		{Int(1<<53 + 1), fits{true, true, false, false}},
+		{Int(-1 << 53), fits{true, false, false, true}},
+		{Int(-1<<53 - 1), fits{true, false, false, false}},
+		{Int(math.MaxInt64), fits{true, true, false, false}},
+		{Int(math.MinInt64), fits{true, false, false, false}},
+		{Uint(1 << 53), fits{true, true, false, true}},
+		{Uint(1<<53 + 1), fits{true, true, false, false}},
+		{Uint(math.MaxInt64), fits{true, true, false, false}},
+		{Uint(math.MaxInt64 + 1), fits{false, true, false, false}},
+		{Uint(math.MaxUint64), fits{false, true, false, false}},
+		{Float64(5), fits{true, true, true, true}},
+		{Float64(-5), fits{true, false, true, true}},
+		{Float64(5.5), fits{false, false, false, true}},
+		{Float64(1 << 63), fits{false, true, false, true}},
+		{Float64(1 << 64), fits{false, false, false, true}},
+		{Float64(math.NaN()), fits{false, false, false, true}},
+		{Float64(math.Inf(1)), fits{false, false, false, true}},
+		{String("42"), fits{true, true, true, true}},
+		{String("-42"), fits{true, false, true, true}},
+		{String("4.0"), fits{true, true, true, true}},
+		{String("4.5"), fits{false, false, false, true}},
+		{String("9007199254740992"), fits{true, true, false, true}},
+		{String("9007199254740993"), fits{true, true, false, false}},
+		{String("9223372036854775807"), fits{true, true, false, false}},
+		{String("18446744073709551615"), fits{false, true, false, false}},
+		{String("12abc"), fits{false, false, false, false}},
+		{String("1e400"), fits{false, false, false, false}},
+		{Nil(), fits{false, false, false, false}},
+		{Bool(true), fits{true, true, true, true}},
+		{Any(Jello{}), fits{false, false, false, false}},
+	}
+	for i, tt := range tests {
+		got := fits{tt.v.FitsInt64(), tt.v.FitsUint64(), tt.v.FitsInt32(),
+			tt.v.FitsFloat64Exactly()}
+		if got != tt.want {
+			t.Fatalf("%d: %#v: expected %+v, got %+v", i, tt.v, tt.want, got)
+		}
+		if strconv.IntSize == 64 {
+			assert(tt.v.FitsInt() == tt.want.i64)
+		}
+		// consistent with the strict conversions
+		_, ok := tt.v.AsInt64()
+		assert(ok == got.i64)
+		_, ok = tt.v.AsUint64()
+		assert(ok == got.u64)
+	}
+	vals := []Value{Int(1), Uint(math.MaxUint64), Float64(1.5)}
+	assert(allocs(func() {
+		for _, v := range vals {
+			v.FitsInt64()
+			v.FitsUint64()
+			v.FitsInt32()
+			v.FitsInt()
+			v.FitsFloat64Exactly()
+		}
+	}) == 0)
+}
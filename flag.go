@@ -0,0 +1,37 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "strconv"
+
+// Set implements flag.Value, allowing a Value to be used with flag.Var.
+// The type is inferred from s. An integer becomes an Int64, or a Uint64
+// when it's too large for an int64, and other numbers become a Float64.
+// The words true and false become a Bool. Anything else is boxed as a
+// String. Set never returns an error.
+func (v *Value) Set(s string) error {
+	if x, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*v = Int64(x)
+	} else if x, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*v = Uint64(x)
+	} else if x, err := strconv.ParseFloat(s, 64); err == nil {
+		*v = Float64(x)
+	} else if s == "true" || s == "True" || s == "TRUE" {
+		*v = Bool(true)
+	} else if s == "false" || s == "False" || s == "FALSE" {
+		*v = Bool(false)
+	} else {
+		*v = String(s)
+	}
+	return nil
+}
+
+// Flag wraps a Value for packages, such as pflag, whose flag value
+// interface requires a `Type() string` method, which conflicts with
+// Value.Type.
+type Flag struct{ Value }
+
+// Type returns the name of the flag type, which is always "value".
+func (f *Flag) Type() string { return "value" }
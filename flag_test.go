@@ -0,0 +1,54 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"flag"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	var limit, big, ratio, verbose, quiet, name, empty Value
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&limit, "limit", "")
+	fs.Var(&big, "big", "")
+	fs.Var(&ratio, "ratio", "")
+	fs.Var(&verbose, "verbose", "")
+	fs.Var(&quiet, "quiet", "")
+	fs.Var(&name, "name", "")
+	fs.Var(&empty, "empty", "")
+	err := fs.Parse([]string{
+		"--limit=-10", "--big", "18446744073709551615", "--ratio=0.5",
+		"--verbose=true", "--quiet=FALSE", "--name=foo", "--empty=",
+	})
+	assert(err == nil)
+	assert(limit.IsInt() && limit.Int() == -10)
+	assert(big.IsUint() && big.Uint64() == math.MaxUint64)
+	assert(ratio.IsFloat() && ratio.Float64() == 0.5)
+	assert(verbose.IsBool() && verbose.Bool())
+	assert(quiet.IsBool() && !quiet.Bool())
+	assert(name.IsString() && name.String() == "foo")
+	assert(empty.String() == "")
+	assert(fs.Lookup("limit").Value.String() == "-10")
+
+	var v Value
+	assert(v.Set("1") == nil && v.IsInt())
+	assert(v.Set("t") == nil && v.IsString())
+	assert(v.Set("1e3") == nil && v.IsFloat() && v.Float64() == 1000)
+
+	// pflag-style flag values
+	var pv interface {
+		String() string
+		Set(string) error
+		Type() string
+	} = &Flag{}
+	assert(pv.Set("10") == nil)
+	assert(pv.Type() == "value")
+	assert(pv.String() == "10")
+	assert(pv.(*Flag).IsInt())
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Format implements fmt.Formatter.
+//
+// The %s and %v verbs print the same text as String(), and %#v prints
+// GoString(). Any other verb is applied to the value returned by Any(),
+// such that %d and %x work for integers, %f and %g for floats, and %q
+// quotes strings and byte slices. Times, percents, durations, IDs, colors,
+// enum sets, and semantic versions are quoted using their String() text by
+// %q. Values boxed with box.Any, other than strings and byte slices, have
+// every verb applied to the underlying value. Flags, width, and precision
+// are respected.
+func (v Value) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, v.GoString())
+		return
+	}
+	v = v.uncorrelated()
+	var arg any
+	if (verb == 's' || verb == 'v') &&
+		(v.isPrim() || v.IsString() || v.IsBytes()) {
+		arg = v.String()
+	} else if verb == 'q' && v.isTextPrim() {
+		arg = v.String()
+	} else {
+		arg = v.Any()
+	}
+	fmt.Fprintf(f, formatDirective(f, verb), arg)
+}
+
+// GoString implements fmt.GoStringer.
+// The value is printed as the constructor call that creates it, such as
+// box.Int64(45) or box.String("hi"). Values boxed with box.Any are printed
+// using the %#v of the underlying value, such as box.Any(main.T{X:1}), and
+// values with a correlation id end with the WithCorrelation call.
+func (v Value) GoString() string {
+	switch v.ptr {
+	case nil:
+		return "box.Nil()"
+	case boolType:
+		return "box.Bool(" + strconv.FormatBool(v.ext != 0) + ")"
+	case int64Type:
+		return "box.Int64(" + strconv.FormatInt(int64(v.ext), 10) + ")"
+	case uint64Type:
+		return "box.Uint64(" + strconv.FormatUint(v.ext, 10) + ")"
+	case float64Type:
+		return fmt.Sprintf("box.Float64(%#v)", math.Float64frombits(v.ext))
+	case custBitsType:
+		return "box.CustomBits(" + strconv.FormatUint(v.ext, 10) + ")"
+	case timeType:
+		return fmt.Sprintf("box.Time(%#v)", v.Time())
+	case percentType:
+		return fmt.Sprintf("box.Percent(%#v)", math.Float64frombits(v.ext))
+	case durationType:
+		return "box.Duration(" + strconv.FormatInt(int64(v.ext), 10) + ")"
+	case idType:
+		return "box.ID(0x" + string(appendID(nil, v.ext)) + ")"
+	case runeType:
+		return "box.Rune(" + strconv.QuoteRune(rune(v.ext)) + ")"
+	case colorType:
+		c := v.color()
+		return fmt.Sprintf("box.RGBA(%#x, %#x, %#x, %#x)", c.R, c.G, c.B, c.A)
+	case enumSetType:
+		return goStringEnumSet(maskCodes(v.ext))
+	case semverType:
+		return fmt.Sprintf("box.SemVer(%d, %d, %d)", uint16(v.ext>>32),
+			uint16(v.ext>>16), uint16(v.ext))
+	}
+	if isExact(v.ptr) {
+		x := v.primToAny()
+		return fmt.Sprintf("box.AnyExact(%T(%#v))", x, x)
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string:
+		if tag := v.Tag(); tag != 0 {
+			return fmt.Sprintf("box.StringWithTag(%q, %d)", vf, tag)
+		}
+		return fmt.Sprintf("box.String(%q)", vf)
+	case *taggedString:
+		return fmt.Sprintf("box.StringWithTag(%q, %d)", vf.str, vf.tag)
+	case []byte:
+		if tag := v.Tag(); tag != 0 {
+			return fmt.Sprintf("box.BytesWithTag(%#v, %d)", vf, tag)
+		}
+		return fmt.Sprintf("box.Bytes(%#v)", vf)
+	case *lazyBytes:
+		return fmt.Sprintf("box.Bytes(%#v)", vf.bytes())
+	case *taggedBytes:
+		return fmt.Sprintf("box.BytesWithTag(%#v, %d)", vf.bytes, vf.tag)
+	case complex128:
+		return fmt.Sprintf("box.Complex128(%#v)", vf)
+	case *enumSet:
+		return goStringEnumSet(vf.codes)
+	case *correlated:
+		return vf.v.GoString() + ".WithCorrelation(" +
+			strconv.FormatUint(vf.id, 10) + ")"
+	default:
+		return fmt.Sprintf("box.Any(%#v)", vf)
+	}
+}
+
+func goStringEnumSet(codes []uint64) string {
+	b := []byte("box.EnumSet(")
+	for i, code := range codes {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = strconv.AppendUint(b, code, 10)
+	}
+	return string(append(b, ')'))
+}
+
+// isTextPrim returns true for primitives whose String() text, rather than
+// their number, is their natural quoted form.
+func (v Value) isTextPrim() bool {
+	switch v.ptr {
+	case timeType, percentType, durationType, idType, colorType, enumSetType,
+		semverType:
+		return true
+	}
+	return false
+}
+
+// formatDirective rebuilds the directive, such as "%-8.2f", that produced
+// the fmt.State.
+func formatDirective(f fmt.State, verb rune) string {
+	b := make([]byte, 1, 16)
+	b[0] = '%'
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			b = append(b, byte(c))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		b = strconv.AppendInt(b, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		b = append(b, '.')
+		b = strconv.AppendInt(b, int64(p), 10)
+	}
+	b = append(b, string(verb)...)
+	return string(b)
+}
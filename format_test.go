@@ -0,0 +1,165 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testFormat(format string, v Value, expect string) {
+	s := fmt.Sprintf(format, v)
+	if s != expect {
+		panic(fmt.Sprintf("%q: expected %q, got %q", format, expect, s))
+	}
+}
+
+func TestFormat(t *testing.T) {
+	// nil
+	testFormat("%v", Nil(), "")
+	testFormat("%s", Nil(), "")
+	testFormat("%5s|", Nil(), "     |")
+	testFormat("%d", Nil(), "%!d(<nil>)")
+	testFormat("%q", Nil(), "%!q(<nil>)")
+
+	// bool
+	testFormat("%v", Bool(true), "true")
+	testFormat("%s", Bool(false), "false")
+	testFormat("%-6v|", Bool(true), "true  |")
+	testFormat("%t", Bool(true), "true")
+
+	// int
+	testFormat("%v", Int(-45), "-45")
+	testFormat("%s", Int(45), "45")
+	testFormat("%d", Int(45), "45")
+	testFormat("%+d", Int(45), "+45")
+	testFormat("%05d", Int(-45), "-0045")
+	testFormat("%-5d|", Int(45), "45   |")
+	testFormat("%x", Int(255), "ff")
+	testFormat("%X", Int(255), "FF")
+	testFormat("%#x", Int(255), "0xff")
+	testFormat("%o", Int(8), "10")
+	testFormat("%b", Int(5), "101")
+	testFormat("%c", Int('A'), "A")
+	testFormat("%f", Int(1), "%!f(int64=1)")
+
+	// uint
+	testFormat("%v", Uint(45), "45")
+	testFormat("%d", Uint(45), "45")
+	testFormat("%8d|", Uint(45), "      45|")
+	testFormat("%x", Uint(255), "ff")
+	testFormat("%o", Uint(8), "10")
+	testFormat("%b", Uint(5), "101")
+	testFormat("%d", CustomBits(7), "7")
+
+	// float
+	testFormat("%v", Float64(1.5), "1.5")
+	testFormat("%s", Float64(1.5), "1.5")
+	testFormat("%f", Float64(1.5), "1.500000")
+	testFormat("%.2f", Float64(1.5), "1.50")
+	testFormat("%8.2f|", Float64(1.5), "    1.50|")
+	testFormat("%g", Float64(1.5), "1.5")
+	testFormat("%e", Float64(1500), "1.500000e+03")
+	testFormat("%.1e", Float64(1500), "1.5e+03")
+	testFormat("%d", Float64(1.5), "%!d(float64=1.5)")
+
+	// string
+	testFormat("%v", String("hi"), "hi")
+	testFormat("%s", String("hi"), "hi")
+	testFormat("%4s|", String("hi"), "  hi|")
+	testFormat("%-4s|", String("hi"), "hi  |")
+	testFormat("%.1s", String("hi"), "h")
+	testFormat("%q", String("hi"), `"hi"`)
+	testFormat("%x", String("hi"), "6869")
+	testFormat("%q", StringWithTag("hi", 1), `"hi"`)
+
+	// bytes
+	testFormat("%v", Bytes([]byte("hi")), "hi")
+	testFormat("%s", Bytes([]byte("hi")), "hi")
+	testFormat("%q", Bytes([]byte("hi")), `"hi"`)
+	testFormat("%x", Bytes([]byte("hi")), "6869")
+	testFormat("% x", Bytes([]byte("hi")), "68 69")
+
+	// strings and bytes through the interface path
+	forceIfaceStrs = true
+	testFormat("%v", String("hi"), "hi")
+	testFormat("%q", String("hi"), `"hi"`)
+	testFormat("%q", StringWithTag("hi", 1), `"hi"`)
+	testFormat("%v", Bytes([]byte("hi")), "hi")
+	testFormat("%x", Bytes([]byte("hi")), "6869")
+	forceIfaceStrs = false
+
+	// other primitives
+	testFormat("%v", Duration(time.Second), "1s")
+	testFormat("%d", Duration(time.Second), "1000000000")
+	testFormat("%6v|", Duration(time.Second), "    1s|")
+	testFormat("%q", Duration(time.Second), `"1s"`)
+	testFormat("%v", Percent(0.5), "50.0%")
+	testFormat("%.2f", Percent(0.5), "0.50")
+	testFormat("%q", Percent(0.5), `"50.0%"`)
+	testFormat("%v", Rune('a'), "a")
+	testFormat("%d", Rune('a'), "97")
+	testFormat("%q", Rune('a'), "'a'")
+	testFormat("%c", Rune('a'), "a")
+	testFormat("%x", ID(255), "ff")
+	testFormat("%q", ID(255), `"00000000000000ff"`)
+	testFormat("%v", RGBA(1, 2, 3, 4), "#01020304")
+	testFormat("%q", RGBA(1, 2, 3, 4), `"#01020304"`)
+	testFormat("%q", EnumSet(1, 3), `"{1, 3}"`)
+	testFormat("%q", Time(time.Unix(0, 0).UTC()), `"1970-01-01T00:00:00Z"`)
+	testFormat("%-22v|", Time(time.Unix(0, 0).UTC()),
+		"1970-01-01T00:00:00Z  |")
+
+	// interface values
+	testFormat("%v", Any(Jello{1, 2}), "{1 2}")
+	testFormat("%+v", Any(Jello{1, 2}), "{Neat:1 Feet:2}")
+	testFormat("%d", Any(Jello{1, 2}), "{1 2}")
+	testFormat("%x", Any(Jello{10, 11}), "{a b}")
+	testFormat("%s", Any(Pudding{1, 2}), "Yum{1 2}")
+	testFormat("%v", Any(&Jello{1, 2}), "&{1 2}")
+	forceIfacePtrs = true
+	testFormat("%v", Any(Jello{1, 2}), "{1 2}")
+	testFormat("%+v", Any(Jello{1, 2}), "{Neat:1 Feet:2}")
+	testFormat("%03d", Any(Jello{1, 2}), "{001 002}")
+	forceIfacePtrs = false
+}
+
+func TestGoString(t *testing.T) {
+	testFormat("%#v", Nil(), "box.Nil()")
+	testFormat("%#v", Bool(true), "box.Bool(true)")
+	testFormat("%#v", Int64(45), "box.Int64(45)")
+	testFormat("%#v", Int(-45), "box.Int64(-45)")
+	testFormat("%#v", Uint64(45), "box.Uint64(45)")
+	testFormat("%#v", Float64(1.5), "box.Float64(1.5)")
+	testFormat("%#v", Float64(1), "box.Float64(1)")
+	testFormat("%#v", CustomBits(7), "box.CustomBits(7)")
+	testFormat("%#v", Percent(0.5), "box.Percent(0.5)")
+	testFormat("%#v", Duration(time.Second), "box.Duration(1000000000)")
+	testFormat("%#v", ID(255), "box.ID(0x00000000000000ff)")
+	testFormat("%#v", Time(time.Unix(0, 0).UTC()),
+		"box.Time(time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC))")
+	testFormat("%#v", String("hi"), `box.String("hi")`)
+	testFormat("%#v", StringWithTag("hi", 7), `box.StringWithTag("hi", 7)`)
+	testFormat("%#v", Bytes([]byte{1, 2}), "box.Bytes([]byte{0x1, 0x2})")
+	testFormat("%#v", Complex128(1+2i), "box.Complex128((1+2i))")
+	testFormat("%#v", Any(Jello{1, 2}),
+		"box.Any(box.Jello{Neat:1, Feet:2})")
+	testFormat("%#v", Any([]Value{Int(1), String("a")}),
+		`box.Any([]box.Value{box.Int64(1), box.String("a")})`)
+	assert(Int(5).GoString() == "box.Int64(5)")
+
+	forceIfaceStrs = true
+	testFormat("%#v", String("hi"), `box.String("hi")`)
+	testFormat("%#v", StringWithTag("hi", 7), `box.StringWithTag("hi", 7)`)
+	testFormat("%#v", Bytes([]byte{1, 2}), "box.Bytes([]byte{0x1, 0x2})")
+	forceIfaceStrs = false
+	forceIfacePtrs = true
+	testFormat("%#v", Any(Jello{1, 2}),
+		"box.Any(box.Jello{Neat:1, Feet:2})")
+	testFormat("%#v", Any(&Jello{1, 2}),
+		"box.Any(&box.Jello{Neat:1, Feet:2})")
+	forceIfacePtrs = false
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"reflect"
+	"time"
+)
+
+// As returns the boxed value as a T.
+// For the bool, integer, float, string, []byte, time.Time, time.Duration,
+// and complex128 types the boxed value must be of the matching kind, such
+// as IsInt() for any of the signed integer types, or IsRune() for a rune,
+// and integers must also fit T, such that As[int8](Int(500)) returns false.
+// These types do not allocate. Any other T is type asserted against Any().
+// Returns the zero value and false when the value is not a T.
+func As[T any](v Value) (t T, ok bool) {
+	switch p := any(&t).(type) {
+	case *bool:
+		if v.IsBool() {
+			*p = v.Bool()
+			return t, true
+		}
+	case *int:
+		if v.IsInt() {
+			*p, ok = v.IntChecked()
+			return t, ok
+		}
+	case *int8:
+		if v.IsInt() {
+			*p, ok = v.Int8Checked()
+			return t, ok
+		}
+	case *int16:
+		if v.IsInt() {
+			*p, ok = v.Int16Checked()
+			return t, ok
+		}
+	case *int32:
+		if v.IsInt() || v.IsRune() {
+			*p, ok = v.Int32Checked()
+			return t, ok
+		}
+	case *int64:
+		if v.IsInt() {
+			*p = v.Int64()
+			return t, true
+		}
+	case *uint:
+		if v.IsUint() {
+			*p, ok = v.UintChecked()
+			return t, ok
+		}
+	case *uint8:
+		if v.IsUint() {
+			*p, ok = v.Uint8Checked()
+			return t, ok
+		}
+	case *uint16:
+		if v.IsUint() {
+			*p, ok = v.Uint16Checked()
+			return t, ok
+		}
+	case *uint32:
+		if v.IsUint() {
+			*p, ok = v.Uint32Checked()
+			return t, ok
+		}
+	case *uint64:
+		if v.IsUint() {
+			*p = v.Uint64()
+			return t, true
+		}
+	case *float32:
+		if v.IsFloat() {
+			*p = v.Float32()
+			return t, true
+		}
+	case *float64:
+		if v.IsFloat() {
+			*p = v.Float64()
+			return t, true
+		}
+	case *string:
+		if v.IsString() {
+			*p = v.String()
+			return t, true
+		}
+	case *[]byte:
+		if v.IsBytes() {
+			*p = v.Bytes()
+			return t, true
+		}
+	case *time.Time:
+		if v.IsTime() {
+			*p = v.Time()
+			return t, true
+		}
+	case *time.Duration:
+		if v.IsDuration() {
+			*p = v.Duration()
+			return t, true
+		}
+	case *complex128:
+		if v.IsComplex() {
+			*p = v.Complex128()
+			return t, true
+		}
+	default:
+		t, ok = v.Any().(T)
+		return t, ok
+	}
+	return t, false
+}
+
+// MustAs returns the boxed value as a T, like As, but panics when the value
+// is not a T, including an integer that does not fit T. The panic message
+// includes both types, such as "box: value is int64, not string".
+func MustAs[T any](v Value) T {
+	t, ok := As[T](v)
+	if !ok {
+		panic("box: value is " + v.TypeName() + ", not " +
+			reflect.TypeOf((*T)(nil)).Elem().String())
+	}
+	return t
+}
+
+// Of boxes x, like box.Any, but the bool, integer, float, string, []byte,
+// time.Time, time.Duration, and complex types are boxed directly using
+// their own constructors, such that Of[int](x) is the same as box.Int(x).
+// Unlike box.Any, boxing an integer or float this way does not allocate.
+// Any other T is boxed using box.Any.
+func Of[T any](x T) Value {
+	switch x := any(x).(type) {
+	case bool:
+		return Bool(x)
+	case int:
+		return Int64(int64(x))
+	case int8:
+		return Int64(int64(x))
+	case int16:
+		return Int64(int64(x))
+	case int32:
+		return Int64(int64(x))
+	case int64:
+		return Int64(x)
+	case uint:
+		return Uint64(uint64(x))
+	case uint8:
+		return Uint64(uint64(x))
+	case uint16:
+		return Uint64(uint64(x))
+	case uint32:
+		return Uint64(uint64(x))
+	case uint64:
+		return Uint64(x)
+	case uintptr:
+		return Uint64(uint64(x))
+	case float32:
+		return Float64(float64(x))
+	case float64:
+		return Float64(x)
+	case string:
+		return String(x)
+	case []byte:
+		return Bytes(x)
+	case time.Time:
+		return Time(x)
+	case time.Duration:
+		return Duration(x)
+	case complex64:
+		return Complex64(x)
+	case complex128:
+		return Complex128(x)
+	}
+	return Any(x)
+}
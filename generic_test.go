@@ -0,0 +1,202 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAs(t *testing.T) {
+	i, ok := As[int](Int(5))
+	assert(ok && i == 5)
+	i8, ok := As[int8](Int(-5))
+	assert(ok && i8 == -5)
+	i64, ok := As[int64](Int(5))
+	assert(ok && i64 == 5)
+	u, ok := As[uint](Uint(5))
+	assert(ok && u == 5)
+	u64, ok := As[uint64](Uint(5))
+	assert(ok && u64 == 5)
+	f, ok := As[float64](Float64(1.5))
+	assert(ok && f == 1.5)
+	f32, ok := As[float32](Float64(1.5))
+	assert(ok && f32 == 1.5)
+	b, ok := As[bool](Bool(true))
+	assert(ok && b)
+	s, ok := As[string](String("hello"))
+	assert(ok && s == "hello")
+	bs, ok := As[[]byte](Bytes([]byte("hello")))
+	assert(ok && string(bs) == "hello")
+	forceIfaceStrs = true
+	s, ok = As[string](StringWithTag("hello", 1))
+	assert(ok && s == "hello")
+	forceIfaceStrs = false
+
+	j, ok := As[Jello](Any(Jello{1, 2}))
+	assert(ok && j.Feet == 2)
+	jp, ok := As[*Jello](Any(&Jello{1, 2}))
+	assert(ok && jp.Neat == 1)
+	st, ok := As[fmt.Stringer](Any(Pudding{1, 2}))
+	assert(ok && st.String() == "Yum{1 2}")
+	a, ok := As[any](Int(5))
+	assert(ok && a.(int64) == 5)
+	r, ok := As[rune](Rune('a'))
+	assert(ok && r == 'a')
+	d, ok := As[time.Duration](Duration(time.Second))
+	assert(ok && d == time.Second)
+
+	// primitives, strings, and bytes do not allocate
+	iv, sv, bv := Int(5), String("hello"), Bytes([]byte("hello"))
+	assert(allocs(func() { i64, ok = As[int64](iv) }) == 0)
+	assert(allocs(func() { i8, ok = As[int8](iv) }) == 0)
+	assert(allocs(func() { s, ok = As[string](sv) }) == 0)
+	assert(allocs(func() { bs, ok = As[[]byte](bv) }) == 0)
+	assert(allocs(func() { _, ok = As[string](iv) }) == 0)
+
+	// failures
+	_, ok = As[int](Uint(5))
+	assert(!ok)
+	_, ok = As[int](String("5"))
+	assert(!ok)
+	_, ok = As[string](Int(1))
+	assert(!ok)
+	_, ok = As[time.Duration](Int(1))
+	assert(!ok)
+	_, ok = As[[]byte](String("hello"))
+	assert(!ok)
+	j, ok = As[Jello](Any(Pudding{1, 2}))
+	assert(!ok && j == Jello{})
+	_, ok = As[Jello](Int(1))
+	assert(!ok)
+	_, ok = As[any](Nil())
+	assert(!ok)
+}
+
+func testAsRange[T comparable](v Value, want T, ok bool) {
+	x, xok := As[T](v)
+	assert(xok == ok && x == want)
+}
+
+func TestAsRange(t *testing.T) {
+	testAsRange[int8](Int(math.MaxInt8), math.MaxInt8, true)
+	testAsRange[int8](Int(math.MinInt8), math.MinInt8, true)
+	testAsRange[int8](Int(math.MaxInt8+1), 0, false)
+	testAsRange[int8](Int(math.MinInt8-1), 0, false)
+	testAsRange[int8](Int(500), 0, false)
+	testAsRange[int16](Int(math.MaxInt16), math.MaxInt16, true)
+	testAsRange[int16](Int(math.MinInt16), math.MinInt16, true)
+	testAsRange[int16](Int(math.MaxInt16+1), 0, false)
+	testAsRange[int16](Int(math.MinInt16-1), 0, false)
+	testAsRange[int32](Int(math.MaxInt32), math.MaxInt32, true)
+	testAsRange[int32](Int(math.MinInt32), math.MinInt32, true)
+	testAsRange[int32](Int64(math.MaxInt32+1), 0, false)
+	testAsRange[int32](Int64(math.MinInt32-1), 0, false)
+	testAsRange[int](Int64(math.MaxInt), math.MaxInt, true)
+	testAsRange[int](Int64(math.MinInt), math.MinInt, true)
+	testAsRange[uint8](Uint(math.MaxUint8), math.MaxUint8, true)
+	testAsRange[uint8](Uint(math.MaxUint8+1), 0, false)
+	testAsRange[uint8](Uint(300), 0, false)
+	testAsRange[uint16](Uint(math.MaxUint16), math.MaxUint16, true)
+	testAsRange[uint16](Uint(math.MaxUint16+1), 0, false)
+	testAsRange[uint32](Uint(math.MaxUint32), math.MaxUint32, true)
+	testAsRange[uint32](Uint64(math.MaxUint32+1), 0, false)
+	testAsRange[uint](Uint64(math.MaxUint), math.MaxUint, true)
+	testAsRange[uint8](Uint(0), 0, true)
+	if math.MaxInt == math.MaxInt32 {
+		testAsRange[int](Int64(math.MaxInt32+1), 0, false)
+		testAsRange[uint](Uint64(math.MaxUint32+1), 0, false)
+	}
+}
+
+func mustAsPanic(fn func()) (msg string) {
+	defer func() {
+		msg, _ = recover().(string)
+	}()
+	fn()
+	return ""
+}
+
+func TestMustAs(t *testing.T) {
+	assert(MustAs[string](String("hello")) == "hello")
+	assert(MustAs[int](Int(5)) == 5)
+	assert(MustAs[Jello](Any(Jello{1, 2})).Feet == 2)
+	v := Int(5)
+	var n int64
+	assert(allocs(func() { n = MustAs[int64](v) }) == 0)
+	assert(n == 5)
+
+	msg := mustAsPanic(func() { MustAs[string](Int(1)) })
+	assert(msg == "box: value is int64, not string")
+	msg = mustAsPanic(func() { MustAs[int](Nil()) })
+	assert(msg == "box: value is nil, not int")
+	msg = mustAsPanic(func() { MustAs[*Jello](Any(Jello{})) })
+	assert(msg == "box: value is box.Jello, not *box.Jello")
+	msg = mustAsPanic(func() { MustAs[fmt.Stringer](Int(1)) })
+	assert(msg == "box: value is int64, not fmt.Stringer")
+
+	// integers that do not fit T are not a T
+	_, ok := As[int32](Int64(math.MaxInt32 + 1))
+	assert(!ok)
+	_, ok = As[int32](Int64(math.MinInt32 - 1))
+	assert(!ok)
+	msg = mustAsPanic(func() { MustAs[int32](Int64(math.MaxInt32 + 1)) })
+	assert(msg == "box: value is int64, not int32")
+	msg = mustAsPanic(func() { MustAs[uint8](Uint(300)) })
+	assert(msg == "box: value is uint64, not uint8")
+}
+
+func TestOf(t *testing.T) {
+	assert(Of(true).IsBool() && Of(true).Bool())
+	assert(Of(5).IsInt() && Of(5).Int() == 5)
+	assert(Of(int8(-5)).IsInt() && Of(int8(-5)).Int() == -5)
+	assert(Of(int32(5)).IsInt())
+	assert(Of(uint(5)).IsUint() && Of(uint16(5)).IsUint())
+	assert(Of(uintptr(5)).IsUint())
+	assert(Of(1.5).IsFloat() && Of(float32(1.5)).Float64() == 1.5)
+	assert(Of("hello").IsString() && Of("hello").String() == "hello")
+	assert(Of([]byte("hello")).IsBytes())
+	assert(Of(time.Second).IsDuration())
+	assert(Of(time.Unix(1, 0).UTC()).IsTime())
+	assert(Of(1 + 2i).IsComplex())
+	assert(Of(Jello{1, 2}).Any().(Jello).Feet == 2)
+	assert(Of(&Jello{1, 2}).Any().(*Jello).Neat == 1)
+	assert(Of[any](5).IsInt())
+	assert(Of[any](nil).IsNil())
+	assert(Of[fmt.Stringer](nil).IsNil())
+
+	var v Value
+	assert(allocs(func() { v = Of(12345) }) == 0)
+	assert(allocs(func() { v = Of(int32(12345)) }) == 0)
+	assert(allocs(func() { v = Of(123.45) }) == 0)
+	assert(allocs(func() { v = Of("hello") }) == 0)
+	assert(v.String() == "hello")
+}
+
+func BenchmarkOf(b *testing.B) {
+	b.Run("Of[int]", func(b *testing.B) {
+		b.ReportAllocs()
+		var n int
+		for i := 0; i < b.N; i++ {
+			n += Of(i + 1000).Int()
+		}
+	})
+	b.Run("Int", func(b *testing.B) {
+		b.ReportAllocs()
+		var n int
+		for i := 0; i < b.N; i++ {
+			n += Int(i + 1000).Int()
+		}
+	})
+	b.Run("Any(int)", func(b *testing.B) {
+		b.ReportAllocs()
+		var n int
+		for i := 0; i < b.N; i++ {
+			n += Any(i + 1000).Int()
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+// binGob is the kind used by GobEncode for values that the binary format
+// cannot represent. The payload is the value encoded by gob.
+const binGob = 0xFF
+
+// GobEncode implements gob.GobEncoder.
+// Values are written using the binary format from MarshalBinary. Other
+// values boxed with box.Any are encoded with gob itself, which means their
+// concrete types must be registered using gob.Register. GobDecode restores
+// those values as the same concrete type, and returns an error when the
+// type is not registered.
+func (v Value) GobEncode() ([]byte, error) {
+	data, err := v.MarshalBinary()
+	if (err == nil && data[1] != binMarshaler) || v.isPrim() {
+		return data, err
+	}
+	var buf bytes.Buffer
+	buf.Write([]byte{binVersion, binGob})
+	vf := v.Any()
+	if err := gob.NewEncoder(&buf).Encode(&vf); err != nil {
+		return nil, fmt.Errorf("box: cannot gob encode %T: %w", vf, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (v *Value) GobDecode(data []byte) error {
+	if len(data) < 2 || data[0] != binVersion || data[1] != binGob {
+		return v.UnmarshalBinary(data)
+	}
+	var vf any
+	err := gob.NewDecoder(bytes.NewReader(data[2:])).Decode(&vf)
+	if err != nil {
+		return fmt.Errorf("box: cannot gob decode value: %w", err)
+	}
+	*v = Any(vf)
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type gobThing struct {
+	Name  string
+	Tags  []string
+	Inner *Jello
+}
+
+func init() {
+	gob.Register(Jello{})
+	gob.Register(&gobThing{})
+}
+
+func sameValue(a, b Value) bool {
+	if a.Type() != b.Type() || a.Tag() != b.Tag() ||
+		a.IsCustomBits() != b.IsCustomBits() {
+		return false
+	}
+	if a.IsBytes() {
+		// encodings keep the bytes, but not whether an empty slice was nil
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}
+	return reflect.DeepEqual(a.Any(), b.Any())
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Name string
+		Vals []Value
+	}
+	vals := append(binaryTestValues(), Any(Jello{1, 2}))
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(record{"hi", vals})
+	assert(err == nil)
+	var rec record
+	err = gob.NewDecoder(&buf).Decode(&rec)
+	assert(err == nil)
+	assert(rec.Name == "hi")
+	assert(len(rec.Vals) == len(vals))
+	for i := range vals {
+		assert(sameValue(rec.Vals[i], vals[i]))
+	}
+	assert(rec.Vals[len(vals)-1].Any().(Jello).Feet == 2)
+
+	var v Value
+	assert(v.GobDecode([]byte{binVersion, binGob, 1, 2, 3}) != nil)
+}
+
+func TestGobRegistered(t *testing.T) {
+	data, err := Any(Jello{1, 2}).GobEncode()
+	assert(err == nil)
+	var v Value
+	assert(v.GobDecode(data) == nil)
+	assert(v.Any().(Jello) == Jello{1, 2})
+
+	thing := &gobThing{"hi", []string{"a", "b"}, &Jello{3, 4}}
+	data, err = Any(thing).GobEncode()
+	assert(err == nil)
+	assert(v.GobDecode(data) == nil)
+	thing2, ok := v.Any().(*gobThing)
+	assert(ok && thing2 != thing)
+	assert(reflect.DeepEqual(thing, thing2))
+
+	forceIfacePtrs = true
+	data, err = Any(Jello{5, 6}).GobEncode()
+	forceIfacePtrs = false
+	assert(err == nil)
+	assert(v.GobDecode(data) == nil)
+	assert(v.Any().(Jello) == Jello{5, 6})
+
+	// unregistered types
+	_, err = Any(Pudding{1, 2}).GobEncode()
+	assert(err != nil)
+	assert(strings.Contains(err.Error(), "box.Pudding"))
+	assert(strings.Contains(err.Error(), "not registered"))
+}
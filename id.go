@@ -0,0 +1,75 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+)
+
+// ID boxes a 64-bit identifier.
+// The id is stored inline and does not allocate. String() renders it as
+// 16 lowercase hex digits, such as ID(255).String() == "00000000000000ff".
+func ID(x uint64) Value {
+	return Value{x, idType}
+}
+
+// RandomID boxes a random 64-bit identifier using src.
+// When src is nil a source private to the package is used, which is seeded
+// from crypto/rand the first time it's needed, so that processes do not
+// produce the same ids.
+func RandomID(src *rand.Rand) Value {
+	if src == nil {
+		idSource.Lock()
+		if idSource.r == nil {
+			idSource.r = newIDSource()
+		}
+		x := idSource.r.Uint64()
+		idSource.Unlock()
+		return ID(x)
+	}
+	return ID(src.Uint64())
+}
+
+var idSource struct {
+	sync.Mutex
+	r *rand.Rand
+}
+
+// newIDSource returns a source seeded from crypto/rand. The global source
+// of math/rand is not used, because before Go 1.20 every process seeds it
+// the same way.
+func newIDSource() *rand.Rand {
+	var seed [8]byte
+	if _, err := cryptorand.Read(seed[:]); err != nil {
+		panic("box: cannot seed random ids: " + err.Error())
+	}
+	x := binary.LittleEndian.Uint64(seed[:])
+	return rand.New(rand.NewSource(int64(x)))
+}
+
+// ID returns the identifier from a value created by box.ID or
+// box.RandomID. Other values are converted using Uint64().
+func (v Value) ID() uint64 {
+	v = v.unwrap()
+	if v.ptr == idType {
+		return v.ext
+	}
+	return v.Uint64()
+}
+
+// IsID returns true if the boxed value was created using box.ID or
+// box.RandomID.
+func (v Value) IsID() bool { return v.unwrap().ptr == idType }
+
+func appendID(dst []byte, x uint64) []byte {
+	const hex = "0123456789abcdef"
+	for i := 60; i >= 0; i -= 4 {
+		dst = append(dst, hex[(x>>uint(i))&0xF])
+	}
+	return dst
+}
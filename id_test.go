@@ -0,0 +1,49 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestID(t *testing.T) {
+	a, b := RandomID(nil), RandomID(nil)
+	assert(a.IsID() && b.IsID())
+	assert(a.ID() != b.ID())
+	assert(a.String() == a.String())
+	assert(len(a.String()) == 16)
+
+	// fresh sources, as in separate processes, do not repeat each other
+	s1, s2 := newIDSource(), newIDSource()
+	same := true
+	for i := 0; i < 4; i++ {
+		same = same && s1.Uint64() == s2.Uint64()
+	}
+	assert(!same)
+
+	r1 := RandomID(rand.New(rand.NewSource(1)))
+	r2 := RandomID(rand.New(rand.NewSource(1)))
+	assert(r1.ID() == r2.ID())
+	assert(r1.String() == r2.String())
+
+	v := ID(255)
+	assert(v.IsID() && !v.IsUint() && !v.IsCustomBits())
+	assert(v.ID() == 255)
+	assert(v.Uint64() == 255)
+	assert(v.String() == "00000000000000ff")
+	assert(string(v.AppendString(nil)) == v.String())
+	assert(ID(math.MaxUint64).String() == "ffffffffffffffff")
+	assert(v.Any().(uint64) == 255)
+	assert(!Uint(255).IsID())
+	assert(Uint(255).ID() == 255)
+
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var v2 Value
+	assert(v2.UnmarshalBinary(data) == nil)
+	assert(v2.IsID() && v2.ID() == 255)
+}
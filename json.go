@@ -0,0 +1,150 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// MarshalJSON implements json.Marshaler.
+//
+// Nil is written as null, bools as true or false, and numbers as JSON
+// numbers. Strings are written as JSON strings and byte slices as base64
+// strings, following the encoding/json convention for []byte. A value from
+// box.CustomBits is written as an unsigned number, and therefore does not
+// come back as custom bits from UnmarshalJSON. Times are written as RFC 3339
+// strings, runes as single character strings, colors as hex strings,
+// semantic versions as "1.2.3" strings, and enum sets as arrays of codes.
+// Paths are written as strings. A map from box.Map is written as an object
+// with its keys in order when every key is a string, and otherwise as an
+// array of [key, value] pairs. Errors are written as the string from their
+// Error method, unless they implement json.Marshaler. Other values boxed
+// with box.Any are encoded using json.Marshal.
+func (v Value) MarshalJSON() ([]byte, error) {
+	switch v.ptr {
+	case nil:
+		return []byte("null"), nil
+	case boolType:
+		return strconv.AppendBool(nil, v.ext != 0), nil
+	case int64Type, durationType:
+		return strconv.AppendInt(nil, int64(v.ext), 10), nil
+	case uint64Type, custBitsType, idType:
+		return strconv.AppendUint(nil, v.ext, 10), nil
+	case float64Type, percentType:
+		return json.Marshal(math.Float64frombits(v.ext))
+	case timeType:
+		return json.Marshal(v.Time())
+	case runeType, colorType, semverType:
+		return json.Marshal(v.String())
+	case enumSetType:
+		return json.Marshal(v.EnumCodes())
+	}
+	if isExact(v.ptr) {
+		return v.widened().MarshalJSON()
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return json.Marshal(v.assertString())
+	case ptrBytes:
+		return json.Marshal(v.assertBytes())
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *taggedString:
+		return json.Marshal(vf.str)
+	case *lazyBytes:
+		return json.Marshal(vf.bytes())
+	case *taggedBytes:
+		return json.Marshal(vf.bytes)
+	case *enumSet:
+		return json.Marshal(vf.codes)
+	case *path:
+		return json.Marshal(vf.raw)
+	case *runLength:
+		vs, _ := v.Slice()
+		return json.Marshal(vs)
+	case *valueMap:
+		return marshalJSONMap(vf)
+	case json.Marshaler:
+		return json.Marshal(vf)
+	case error:
+		return json.Marshal(vf.Error())
+	default:
+		return json.Marshal(vf)
+	}
+}
+
+func marshalJSONMap(m *valueMap) ([]byte, error) {
+	object := true
+	for _, key := range m.keys {
+		if !key.IsString() {
+			object = false
+			break
+		}
+	}
+	dst := []byte{'['}
+	if object {
+		dst[0] = '{'
+	}
+	for i := range m.keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if !object {
+			dst = append(dst, '[')
+		}
+		key, err := m.keys[i].MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		val, err := m.vals[i].MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, key...)
+		if object {
+			dst = append(dst, ':')
+		} else {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, val...)
+		if !object {
+			dst = append(dst, ']')
+		}
+	}
+	if object {
+		return append(dst, '}'), nil
+	}
+	return append(dst, ']'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Numbers without a fraction or exponent become an Int64, or a Uint64 when
+// too large for an int64. All other numbers become a Float64. Strings
+// become a String, and null becomes Nil. Objects and arrays are decoded
+// using encoding/json into a map[string]any or []any and boxed with
+// box.Any.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var x any
+	if err := json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+	if f, ok := x.(float64); ok {
+		s := string(bytes.TrimSpace(data))
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			*v = Int64(i)
+		} else if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			*v = Uint64(u)
+		} else {
+			*v = Float64(f)
+		}
+		return nil
+	}
+	*v = Any(x)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func jsonString(v Value) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return string(data)
+}
+
+func TestJSONMarshal(t *testing.T) {
+	assert(jsonString(Nil()) == `null`)
+	assert(jsonString(Bool(true)) == `true`)
+	assert(jsonString(Bool(false)) == `false`)
+	assert(jsonString(Int64(-123)) == `-123`)
+	assert(jsonString(Uint64(math.MaxUint64)) == `18446744073709551615`)
+	assert(jsonString(Float64(1.5)) == `1.5`)
+	assert(jsonString(CustomBits(99)) == `99`)
+	assert(jsonString(String("hi \"there\"")) == `"hi \"there\""`)
+	assert(jsonString(StringWithTag("hi", 7)) == `"hi"`)
+	assert(jsonString(Bytes([]byte("hello"))) == `"aGVsbG8="`)
+	assert(jsonString(Any(Jello{1, 2})) == `{"Neat":1,"Feet":2}`)
+	assert(jsonString(Any([]int{1, 2})) == `[1,2]`)
+	assert(jsonString(Time(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))) ==
+		`"2023-01-02T03:04:05Z"`)
+	assert(jsonString(Duration(time.Second)) == `1000000000`)
+	assert(jsonString(Percent(0.5)) == `0.5`)
+	forceIfaceStrs = true
+	assert(jsonString(String("hi")) == `"hi"`)
+	assert(jsonString(StringWithTag("hi", 7)) == `"hi"`)
+	assert(jsonString(Bytes([]byte("hello"))) == `"aGVsbG8="`)
+	forceIfaceStrs = false
+	_, err := json.Marshal(Float64(math.NaN()))
+	assert(err != nil)
+}
+
+func TestJSONUnmarshal(t *testing.T) {
+	var v Value
+	assert(json.Unmarshal([]byte(`null`), &v) == nil && v.IsNil())
+	assert(json.Unmarshal([]byte(`true`), &v) == nil && v.IsBool() &&
+		v.Bool())
+	assert(json.Unmarshal([]byte(` -123 `), &v) == nil && v.IsInt() &&
+		v.Int64() == -123)
+	assert(json.Unmarshal([]byte(`18446744073709551615`), &v) == nil &&
+		v.IsUint() && v.Uint64() == math.MaxUint64)
+	assert(json.Unmarshal([]byte(`1.0`), &v) == nil && v.IsFloat() &&
+		v.Float64() == 1)
+	assert(json.Unmarshal([]byte(`1e3`), &v) == nil && v.IsFloat() &&
+		v.Float64() == 1000)
+	assert(json.Unmarshal([]byte(`"hello"`), &v) == nil && v.IsString() &&
+		v.String() == "hello")
+	assert(json.Unmarshal([]byte(`[1,"a"]`), &v) == nil &&
+		len(v.Any().([]any)) == 2)
+	assert(json.Unmarshal([]byte(`{"a":1}`), &v) == nil &&
+		v.Any().(map[string]any)["a"] == 1.0)
+	assert(json.Unmarshal([]byte(`{`), &v) != nil)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type doc struct {
+		A Value
+		B Value
+		C Value
+		D Value
+		E Value
+		F []Value
+	}
+	in := doc{Nil(), Bool(true), Int(-5), Float64(2.5), String("hello"),
+		[]Value{Uint64(math.MaxUint64), String("x"), Nil()}}
+	data, err := json.Marshal(in)
+	assert(err == nil)
+	assert(string(data) == `{"A":null,"B":true,"C":-5,"D":2.5,`+
+		`"E":"hello","F":[18446744073709551615,"x",null]}`)
+	var out doc
+	assert(json.Unmarshal(data, &out) == nil)
+	assert(out.A.IsNil())
+	assert(out.B.IsBool() && out.B.Bool())
+	assert(out.C.IsInt() && out.C.Int() == -5)
+	assert(out.D.IsFloat() && out.D.Float64() == 2.5)
+	assert(out.E.IsString() && out.E.String() == "hello")
+	assert(len(out.F) == 3)
+	assert(out.F[0].IsUint() && out.F[0].Uint64() == math.MaxUint64)
+	assert(out.F[1].String() == "x")
+	assert(out.F[2].IsNil())
+
+	// bytes come back as their base64 string
+	data, _ = json.Marshal(Bytes([]byte("hello")))
+	var v Value
+	assert(json.Unmarshal(data, &v) == nil && v.String() == "aGVsbG8=")
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"strconv"
+	"time"
+)
+
+// Kind is the kind of a boxed value.
+type Kind uint8
+
+const (
+	KindNil      Kind = iota // box.Nil
+	KindBool                 // box.Bool
+	KindInt                  // box.Int64 and other signed integers
+	KindUint                 // box.Uint64 and other unsigned integers
+	KindFloat                // box.Float64 and box.Float32
+	KindCustom               // box.CustomBits
+	KindString               // box.String and box.StringWithTag
+	KindBytes                // box.Bytes and box.LazyBytes
+	KindAny                  // any other value boxed with box.Any
+	KindTime                 // box.Time
+	KindPercent              // box.Percent
+	KindDuration             // box.Duration
+	KindID                   // box.ID and box.RandomID
+	KindRune                 // box.Rune
+	KindColor                // box.RGBA
+	KindEnumSet              // box.EnumSet
+	KindSemVer               // box.SemVer
+	KindMap                  // box.Map
+)
+
+var kindNames = [...]string{
+	KindNil:      "nil",
+	KindBool:     "bool",
+	KindInt:      "int",
+	KindUint:     "uint",
+	KindFloat:    "float",
+	KindCustom:   "custom",
+	KindString:   "string",
+	KindBytes:    "bytes",
+	KindAny:      "any",
+	KindTime:     "time",
+	KindPercent:  "percent",
+	KindDuration: "duration",
+	KindID:       "id",
+	KindRune:     "rune",
+	KindColor:    "color",
+	KindEnumSet:  "enumset",
+	KindSemVer:   "semver",
+	KindMap:      "map",
+}
+
+// String returns the name of the kind, such as "int" or "string".
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Kind returns the kind of the boxed value.
+// Strings and byte slices report KindString and KindBytes even when they
+// are too large to be stored inline and are boxed using the same path as
+// box.Any.
+func (v Value) Kind() Kind {
+	switch v.ptr {
+	case nil:
+		return KindNil
+	case boolType:
+		return KindBool
+	case int64Type, intType, int8Type, int16Type, int32Type:
+		return KindInt
+	case uint64Type, uintType, uint8Type, uint16Type, uint32Type,
+		uintptrType:
+		return KindUint
+	case float64Type, float32Type:
+		return KindFloat
+	case complex64Type:
+		return KindAny
+	case custBitsType:
+		return KindCustom
+	case timeType:
+		return KindTime
+	case percentType:
+		return KindPercent
+	case durationType:
+		return KindDuration
+	case idType:
+		return KindID
+	case runeType:
+		return KindRune
+	case colorType:
+		return KindColor
+	case enumSetType:
+		return KindEnumSet
+	case semverType:
+		return KindSemVer
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return KindString
+	case ptrBytes:
+		return KindBytes
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *correlated:
+		return vf.v.Kind()
+	case string, *taggedString:
+		return KindString
+	case []byte, *lazyBytes, *taggedBytes:
+		return KindBytes
+	case time.Time:
+		return KindTime
+	case *enumSet:
+		return KindEnumSet
+	case *valueMap:
+		return KindMap
+	default:
+		return KindAny
+	}
+}
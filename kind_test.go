@@ -0,0 +1,48 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKind(t *testing.T) {
+	assert(Nil().Kind() == KindNil)
+	assert(Bool(true).Kind() == KindBool)
+	assert(Int8(1).Kind() == KindInt)
+	assert(Uint(1).Kind() == KindUint)
+	assert(Float32(1).Kind() == KindFloat)
+	assert(CustomBits(1).Kind() == KindCustom)
+	assert(String("a").Kind() == KindString)
+	assert(StringWithTag("a", 1).Kind() == KindString)
+	assert(Bytes([]byte("a")).Kind() == KindBytes)
+	assert(LazyBytes(strings.NewReader("a"), 0, 1).Kind() == KindBytes)
+	assert(Time(time.Unix(1, 0).UTC()).Kind() == KindTime)
+	assert(Time(time.Unix(1, 0)).Kind() == KindTime)
+	assert(Percent(0.5).Kind() == KindPercent)
+	assert(Duration(time.Second).Kind() == KindDuration)
+	assert(ID(1).Kind() == KindID)
+	assert(Complex128(1i).Kind() == KindAny)
+	assert(Any(Jello{}).Kind() == KindAny)
+	assert(Any(&Jello{}).Kind() == KindAny)
+
+	forceIfaceStrs = true
+	assert(String("a").Kind() == KindString)
+	assert(StringWithTag("a", 1).Kind() == KindString)
+	assert(Bytes([]byte("a")).Kind() == KindBytes)
+	forceIfaceStrs = false
+	forceIfacePtrs = true
+	assert(Any(Jello{}).Kind() == KindAny)
+	forceIfacePtrs = false
+
+	assert(KindNil.String() == "nil")
+	assert(KindInt.String() == "int")
+	assert(KindBytes.String() == "bytes")
+	assert(KindAny.String() == "any")
+	assert(KindID.String() == "id")
+	assert(Kind(200).String() == "Kind(200)")
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// Layout describes how a value is stored. It is meant for tests that assert
+// the layout of boxed values, and is not stable across versions.
+type Layout struct {
+	// Ext is the raw ext word.
+	Ext uint64
+	// Tag is the low byte of Ext, which says how the ptr word is read when
+	// the value is not a primitive. It is zero for primitives.
+	Tag byte
+	// Prim is true when the ptr word is nil or a primitive type sentinel,
+	// and Ext holds the data itself.
+	Prim bool
+}
+
+// Layout returns the memory layout of the value.
+func (v Value) Layout() Layout {
+	l := Layout{Ext: v.ext, Prim: v.isPrim()}
+	if !l.Prim {
+		l.Tag = byte(v.ext)
+	}
+	return l
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "testing"
+
+func TestLayout(t *testing.T) {
+	l := Int(-1).Layout()
+	assert(l.Prim && l.Tag == 0 && l.Ext == 1<<64-1)
+	l = Nil().Layout()
+	assert(l.Prim && l.Tag == 0 && l.Ext == 0)
+
+	l = String("hello").Layout()
+	assert(!l.Prim && l.Tag == ptrString && l.Ext>>32 == 5)
+	l = StringWithTag("hello", 7).Layout()
+	assert(!l.Prim && l.Tag == ptrString && uint16(l.Ext>>8) == 7)
+	l = Bytes([]byte("hi")).Layout()
+	assert(!l.Prim && l.Tag == ptrBytes && l.Ext>>32 == 2)
+	l = Any(Jello{}).Layout()
+	assert(!l.Prim && (l.Tag == ptrIface || l.Tag == ptrIfacePtr))
+
+	forceIfaceStrs = true
+	l = String("hello").Layout()
+	assert(!l.Prim && l.Tag != ptrString)
+	forceIfaceStrs = false
+}
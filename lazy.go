@@ -0,0 +1,91 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// lazyBytes is a byte range that is not read until it's needed.
+type lazyBytes struct {
+	r    io.ReaderAt
+	off  int64
+	n    int
+	once sync.Once
+	data []byte
+}
+
+func (lb *lazyBytes) bytes() []byte {
+	lb.once.Do(func() {
+		data := make([]byte, lb.n)
+		n, err := lb.r.ReadAt(data, lb.off)
+		if n == len(data) && (err == nil || err == io.EOF) {
+			lb.data = data
+		}
+	})
+	return lb.data
+}
+
+func (lb *lazyBytes) String() string { return string(lb.bytes()) }
+func (lb *lazyBytes) Float64() float64 {
+	if len(lb.bytes()) == 0 {
+		return math.NaN()
+	}
+	return Bytes(lb.bytes()).Float64()
+}
+func (lb *lazyBytes) Int64() int64   { return Bytes(lb.bytes()).Int64() }
+func (lb *lazyBytes) Uint64() uint64 { return Bytes(lb.bytes()).Uint64() }
+func (lb *lazyBytes) Bool() bool     { return Bytes(lb.bytes()).Bool() }
+
+// LazyBytes boxes a byte range of length bytes starting at off in r.
+// Nothing is read until the contents are needed by Bytes(), String(), or
+// one of the conversion methods. The range is read once and cached.
+// When the read fails the value behaves as an empty byte slice.
+// Len() returns the length without reading.
+func LazyBytes(r io.ReaderAt, off int64, length int) Value {
+	return toIface(&lazyBytes{r: r, off: off, n: length})
+}
+
+// Len returns the length of a boxed string or byte slice, without reading
+// the contents, or the number of entries in a map from box.Map. For other
+// values boxed with box.Any, Len returns the length of a string, slice,
+// map, array, or channel.
+// Returns -1 for values that have no length, such as numbers and nil.
+func (v Value) Len() int {
+	v = v.unwrap()
+	if v.isPrim() {
+		return -1
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return int(v.ext >> 32)
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string:
+		return len(vf)
+	case []byte:
+		return len(vf)
+	case *taggedString:
+		return len(vf.str)
+	case *lazyBytes:
+		return vf.n
+	case *runLength:
+		return vf.count
+	case *taggedBytes:
+		return len(vf.bytes)
+	case *valueMap:
+		return len(vf.keys)
+	default:
+		switch rv := reflect.ValueOf(vf); rv.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array,
+			reflect.Chan:
+			return rv.Len()
+		}
+	}
+	return -1
+}
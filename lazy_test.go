@@ -0,0 +1,82 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+type countingReaderAt struct {
+	r     *strings.Reader
+	reads int
+}
+
+func (cr *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	cr.reads++
+	return cr.r.ReadAt(p, off)
+}
+
+type failingReaderAt struct{}
+
+func (failingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	return 0, errors.New("failed")
+}
+
+func TestLazyBytes(t *testing.T) {
+	r := &countingReaderAt{r: strings.NewReader("hello 12345 world")}
+	v := LazyBytes(r, 6, 5)
+	assert(v.Len() == 5)
+	assert(v.IsBytes())
+	assert(r.reads == 0)
+	assert(string(v.Bytes()) == "12345")
+	assert(v.String() == "12345")
+	assert(v.Int64() == 12345)
+	assert(r.reads == 1)
+
+	v = LazyBytes(r, 12, 10)
+	assert(v.Len() == 10)
+	assert(len(v.Bytes()) == 0)
+	assert(v.String() == "")
+
+	v = LazyBytes(failingReaderAt{}, 0, 5)
+	assert(v.Len() == 5)
+	assert(len(v.Bytes()) == 0)
+	assert(math.IsNaN(v.Float64()))
+	assert(v.Int64() == 0)
+}
+
+func TestLen(t *testing.T) {
+	assert(Nil().Len() == -1)
+	assert(Int(10).Len() == -1)
+	assert(String("hello").Len() == 5)
+	assert(StringWithTag("hello", 1).Len() == 5)
+	assert(Bytes([]byte("hello")).Len() == 5)
+	forceIfaceStrs = true
+	assert(String("hello").Len() == 5)
+	assert(StringWithTag("hello", 1).Len() == 5)
+	assert(Bytes([]byte("hello")).Len() == 5)
+	assert(String("").Len() == 0)
+	forceIfaceStrs = false
+	assert(Bool(true).Len() == -1)
+	assert(Uint(10).Len() == -1)
+	assert(Float64(1.5).Len() == -1)
+	assert(CustomBits(10).Len() == -1)
+	assert(Duration(10).Len() == -1)
+	assert(Bytes(make([]byte, 3, 10)).Len() == 3)
+	assert(Any([]int{1, 2, 3}).Len() == 3)
+	assert(Any(map[string]int{"a": 1}).Len() == 1)
+	assert(Any([4]int{}).Len() == 4)
+	assert(Any(make(chan int, 2)).Len() == 0)
+	assert(Any(Jello{}).Len() == -1)
+	assert(Any(&Jello{}).Len() == -1)
+	assert(Complex128(1).Len() == -1)
+	forceIfacePtrs = true
+	assert(Any([]int{1, 2, 3}).Len() == 3)
+	assert(Any(Jello{}).Len() == -1)
+	forceIfacePtrs = false
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"net/url"
+	"reflect"
+)
+
+// ToMap folds a boxed []any of key/value entries into a boxed
+// map[string]any. Each entry must be a two-element []any or []Value, where
+// the first element is the key, converted using String(), and the second
+// is the value. When a key appears more than once, the last entry wins.
+// Returns false if the value is not a []any or any of its entries is not a
+// two-element slice.
+func (v Value) ToMap() (Value, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return Nil(), false
+	}
+	entries, ok := v.assertNonPrimAny().([]any)
+	if !ok {
+		return Nil(), false
+	}
+	m := make(map[string]any, len(entries))
+	for _, e := range entries {
+		switch e := e.(type) {
+		case []any:
+			if len(e) != 2 {
+				return Nil(), false
+			}
+			m[Any(e[0]).String()] = e[1]
+		case []Value:
+			if len(e) != 2 {
+				return Nil(), false
+			}
+			m[e[0].String()] = e[1].Any()
+		default:
+			return Nil(), false
+		}
+	}
+	return Any(m), true
+}
+
+// URLValues converts a boxed map with string keys, such as a
+// map[string]any, or a value created by box.Map, into url.Values for form
+// encoding. Each value is added using its String() text, and a slice or
+// array adds one entry per element. A nested map is flattened using the
+// bracket convention, such that {"a": {"b": 1}} adds "a[b]=1". Byte slices
+// are added as text, not expanded. Returns false for any other value.
+func (v Value) URLValues() (url.Values, bool) {
+	v = v.unwrap()
+	if v.isPrim() || v.IsString() || v.IsBytes() {
+		return nil, false
+	}
+	vals := url.Values{}
+	if !addURLValues(vals, "", v) {
+		return nil, false
+	}
+	return vals, true
+}
+
+// addURLValues adds the entries of a map value, with each key nested under
+// prefix when it's not empty. Returns false if v is not a map with string
+// keys.
+func addURLValues(vals url.Values, prefix string, v Value) bool {
+	key := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "[" + k + "]"
+	}
+	if keys, mvals, ok := v.Map(); ok {
+		for i := range keys {
+			addURLValue(vals, key(keys[i].String()), mvals[i])
+		}
+		return true
+	}
+	rv := reflect.ValueOf(v.assertNonPrimAny())
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return false
+	}
+	iter := rv.MapRange()
+	for iter.Next() {
+		addURLValue(vals, key(iter.Key().String()),
+			boxOf(iter.Value().Interface()))
+	}
+	return true
+}
+
+func addURLValue(vals url.Values, key string, v Value) {
+	v = v.unwrap()
+	if v.isPrim() || v.IsString() || v.IsBytes() {
+		vals.Add(key, v.String())
+		return
+	}
+	if addURLValues(vals, key, v) {
+		return
+	}
+	switch rv := reflect.ValueOf(v.assertNonPrimAny()); rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			vals.Add(key, boxOf(rv.Index(i).Interface()).String())
+		}
+	default:
+		vals.Add(key, v.String())
+	}
+}
+
+// boxOf boxes x, unless it's already a Value.
+func boxOf(x any) Value {
+	if v, ok := x.(Value); ok {
+		return v
+	}
+	return Any(x)
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToMap(t *testing.T) {
+	v, ok := Any([]any{
+		[]any{"a", 1},
+		[]any{2, "b"},
+		[]Value{String("c"), Bool(true)},
+		[]any{"a", 3},
+	}).ToMap()
+	assert(ok)
+	m := v.Any().(map[string]any)
+	assert(len(m) == 3)
+	assert(m["a"] == 3)
+	assert(m["2"] == "b")
+	assert(m["c"] == true)
+
+	v, ok = Any([]any{}).ToMap()
+	assert(ok && len(v.Any().(map[string]any)) == 0)
+
+	_, ok = Any([]any{[]any{"a"}}).ToMap()
+	assert(!ok)
+	_, ok = Any([]any{"a", 1}).ToMap()
+	assert(!ok)
+	_, ok = Any([]int{1, 2}).ToMap()
+	assert(!ok)
+	_, ok = String("a").ToMap()
+	assert(!ok)
+	_, ok = Int(1).ToMap()
+	assert(!ok)
+}
+
+func TestURLValues(t *testing.T) {
+	v := Any(map[string]any{
+		"name":  "box",
+		"n":     5,
+		"ok":    true,
+		"tags":  []string{"a", "b"},
+		"ids":   []any{1, "two", 3.5},
+		"vals":  []Value{Int(1), String("x")},
+		"empty": nil,
+		"raw":   []byte("hi"),
+		"sub":   map[string]any{"x": 1, "y": []int{2, 3}},
+	})
+	vals, ok := v.URLValues()
+	assert(ok)
+	assert(vals.Get("name") == "box" && vals.Get("n") == "5")
+	assert(vals.Get("ok") == "true" && vals.Get("empty") == "")
+	assert(reflect.DeepEqual(vals["tags"], []string{"a", "b"}))
+	assert(reflect.DeepEqual(vals["ids"], []string{"1", "two", "3.5"}))
+	assert(reflect.DeepEqual(vals["vals"], []string{"1", "x"}))
+	assert(vals.Get("raw") == "hi")
+	assert(vals.Get("sub[x]") == "1")
+	assert(reflect.DeepEqual(vals["sub[y]"], []string{"2", "3"}))
+	assert(len(vals) == 10)
+	assert(vals.Encode() == "empty=&ids=1&ids=two&ids=3.5&n=5&name=box&"+
+		"ok=true&raw=hi&sub%5Bx%5D=1&sub%5By%5D=2&sub%5By%5D=3&tags=a&"+
+		"tags=b&vals=1&vals=x")
+
+	vals, ok = Any(map[string]string{"a": "1"}).URLValues()
+	assert(ok && vals.Get("a") == "1")
+	vals, ok = Map([]Value{String("k"), Int(2)},
+		[]Value{Slice([]Value{Int(1), Int(2)}), Bool(false)}).URLValues()
+	assert(ok && reflect.DeepEqual(vals["k"], []string{"1", "2"}))
+	assert(vals.Get("2") == "false")
+
+	for _, v := range []Value{Nil(), Int(1), String("a=b"), Bytes(nil),
+		Any([]any{1}), Any(map[int]any{1: 2}), Any(Jello{})} {
+		_, ok := v.URLValues()
+		assert(!ok)
+	}
+}
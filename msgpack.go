@@ -0,0 +1,413 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// MsgpackAppender is implemented by values that can append their own
+// MessagePack encoding. AppendMsgpack uses it for values boxed with
+// box.Any.
+type MsgpackAppender interface {
+	AppendMsgpack(dst []byte) []byte
+}
+
+var errMsgpackShort = errors.New("box: msgpack data too short")
+var errMsgpackDepth = errors.New("box: msgpack nested too deeply")
+
+// AppendMsgpack appends the MessagePack encoding of the value to dst.
+//
+// Nil is written as nil, bools as true or false, and integers using the
+// most compact integer format. Floats and percents are written as float64,
+// strings using the str formats, and byte slices using the bin formats.
+// Times use the timestamp extension type, durations are written as integer
+// nanoseconds, runes and colors as their String() text, and enum sets as
+// arrays of codes. Values from box.Slice, box.RunLength, and box.Map are
+// written as arrays and maps. A value boxed with box.Any that implements
+// MsgpackAppender appends its own encoding, and any other value is written
+// as its String() text.
+//
+// Primitives, strings, and byte slices do not allocate.
+func (v Value) AppendMsgpack(dst []byte) []byte {
+	v = v.unwrap()
+	switch v.ptr {
+	case nil:
+		return append(dst, 0xc0)
+	case boolType:
+		if v.ext != 0 {
+			return append(dst, 0xc3)
+		}
+		return append(dst, 0xc2)
+	case int64Type, durationType:
+		return appendMsgpackInt(dst, int64(v.ext))
+	case uint64Type, custBitsType, idType:
+		return appendMsgpackUint(dst, v.ext)
+	case float64Type, percentType:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcb), v.ext)
+	case timeType:
+		return appendMsgpackTime(dst, v.Time())
+	case enumSetType:
+		return appendMsgpackEnumSet(dst, maskCodes(v.ext))
+	}
+	if v.isPrim() {
+		return appendMsgpackStr(dst, v.primToString())
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return appendMsgpackStr(dst, v.assertString())
+	case ptrBytes:
+		return appendMsgpackBin(dst, v.assertBytes())
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string:
+		return appendMsgpackStr(dst, vf)
+	case *taggedString:
+		return appendMsgpackStr(dst, vf.str)
+	case []byte:
+		return appendMsgpackBin(dst, vf)
+	case *lazyBytes:
+		return appendMsgpackBin(dst, vf.bytes())
+	case *taggedBytes:
+		return appendMsgpackBin(dst, vf.bytes)
+	case time.Time:
+		return appendMsgpackTime(dst, vf)
+	case *enumSet:
+		return appendMsgpackEnumSet(dst, vf.codes)
+	case []Value:
+		return appendMsgpackArray(dst, vf)
+	case Values:
+		return appendMsgpackArray(dst, vf)
+	case *runLength:
+		dst = appendMsgpackHeader(dst, vf.count, 0x90, 0xdc)
+		for i := 0; i < vf.count; i++ {
+			dst = vf.v.AppendMsgpack(dst)
+		}
+		return dst
+	case *valueMap:
+		dst = appendMsgpackHeader(dst, len(vf.keys), 0x80, 0xde)
+		for i := range vf.keys {
+			dst = vf.keys[i].AppendMsgpack(dst)
+			dst = vf.vals[i].AppendMsgpack(dst)
+		}
+		return dst
+	case MsgpackAppender:
+		return vf.AppendMsgpack(dst)
+	default:
+		return appendMsgpackStr(dst, v.String())
+	}
+}
+
+func appendMsgpackInt(dst []byte, x int64) []byte {
+	switch {
+	case x >= 0:
+		return appendMsgpackUint(dst, uint64(x))
+	case x >= -32:
+		return append(dst, byte(x))
+	case x >= math.MinInt8:
+		return append(dst, 0xd0, byte(x))
+	case x >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xd1), uint16(x))
+	case x >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xd2), uint32(x))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xd3), uint64(x))
+	}
+}
+
+func appendMsgpackUint(dst []byte, x uint64) []byte {
+	switch {
+	case x <= 0x7f:
+		return append(dst, byte(x))
+	case x <= math.MaxUint8:
+		return append(dst, 0xcc, byte(x))
+	case x <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xcd), uint16(x))
+	case x <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xce), uint32(x))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcf), x)
+	}
+}
+
+func appendMsgpackStr(dst []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		dst = append(dst, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xda), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xdb), uint32(n))
+	}
+	return append(dst, s...)
+}
+
+func appendMsgpackBin(dst []byte, b []byte) []byte {
+	switch n := len(b); {
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xc5), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xc6), uint32(n))
+	}
+	return append(dst, b...)
+}
+
+// appendMsgpackHeader appends an array or map header, using the fix format
+// for up to 15 entries and otherwise the 16 or 32 bit format that follows
+// code16.
+func appendMsgpackHeader(dst []byte, n int, fix, code16 byte) []byte {
+	switch {
+	case n <= 15:
+		return append(dst, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, code16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, code16+1),
+			uint32(n))
+	}
+}
+
+func appendMsgpackArray(dst []byte, vals []Value) []byte {
+	dst = appendMsgpackHeader(dst, len(vals), 0x90, 0xdc)
+	for _, v := range vals {
+		dst = v.AppendMsgpack(dst)
+	}
+	return dst
+}
+
+func appendMsgpackEnumSet(dst []byte, codes []uint64) []byte {
+	dst = appendMsgpackHeader(dst, len(codes), 0x90, 0xdc)
+	for _, code := range codes {
+		dst = appendMsgpackUint(dst, code)
+	}
+	return dst
+}
+
+// appendMsgpackTime appends the timestamp extension type, which is -1,
+// using the 32, 64, or 96 bit format.
+func appendMsgpackTime(dst []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	if uint64(sec)>>34 == 0 {
+		if nsec == 0 && sec <= math.MaxUint32 {
+			return binary.BigEndian.AppendUint32(append(dst, 0xd6, 0xff),
+				uint32(sec))
+		}
+		return binary.BigEndian.AppendUint64(append(dst, 0xd7, 0xff),
+			nsec<<34|uint64(sec))
+	}
+	dst = binary.BigEndian.AppendUint32(append(dst, 0xc7, 12, 0xff),
+		uint32(nsec))
+	return binary.BigEndian.AppendUint64(dst, uint64(sec))
+}
+
+// ReadMsgpack decodes a single MessagePack value from the front of data
+// and returns the number of bytes read.
+//
+// Integers become an Int64, or a Uint64 when too large for an int64. Both
+// float formats become a Float64, str becomes a String, and bin becomes a
+// Bytes that is copied from data. Arrays and maps become box.Slice and
+// box.Map values, and timestamps become a Time. Other extension types
+// return an error, as do arrays and maps nested more than 10000 levels
+// deep.
+func ReadMsgpack(data []byte) (Value, int, error) {
+	return readMsgpack(data, 0)
+}
+
+// maxMsgpackDepth limits how deeply arrays and maps may nest, so that
+// untrusted input cannot overflow the stack.
+const maxMsgpackDepth = 10000
+
+func readMsgpack(data []byte, depth int) (Value, int, error) {
+	if len(data) == 0 {
+		return Value{}, 0, errMsgpackShort
+	}
+	c := data[0]
+	switch {
+	case c <= 0x7f:
+		return Int64(int64(c)), 1, nil
+	case c >= 0xe0:
+		return Int64(int64(int8(c))), 1, nil
+	case c&0xe0 == 0xa0:
+		return readMsgpackStr(data, 1, int(c&0x1f))
+	case c&0xf0 == 0x90:
+		return readMsgpackArray(data, 1, int(c&0x0f), depth)
+	case c&0xf0 == 0x80:
+		return readMsgpackMap(data, 1, int(c&0x0f), depth)
+	}
+	switch c {
+	case 0xc0:
+		return Nil(), 1, nil
+	case 0xc2, 0xc3:
+		return Bool(c == 0xc3), 1, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		x, n, err := readMsgpackN(data, 1, 1<<(c-0xcc))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		if x > math.MaxInt64 {
+			return Uint64(x), n, nil
+		}
+		return Int64(int64(x)), n, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		x, n, err := readMsgpackN(data, 1, size)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		// sign extend
+		shift := 64 - 8*size
+		return Int64(int64(x<<shift) >> shift), n, nil
+	case 0xca:
+		x, n, err := readMsgpackN(data, 1, 4)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return Float64(float64(math.Float32frombits(uint32(x)))), n, nil
+	case 0xcb:
+		x, n, err := readMsgpackN(data, 1, 8)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return Float64(math.Float64frombits(x)), n, nil
+	case 0xd9, 0xda, 0xdb:
+		size, n, err := readMsgpackN(data, 1, 1<<(c-0xd9))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return readMsgpackStr(data, n, int(size))
+	case 0xc4, 0xc5, 0xc6:
+		size, n, err := readMsgpackN(data, 1, 1<<(c-0xc4))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		if size > uint64(len(data)-n) {
+			return Value{}, 0, errMsgpackShort
+		}
+		b := append([]byte{}, data[n:n+int(size)]...)
+		return Bytes(b), n + int(size), nil
+	case 0xdc, 0xdd:
+		size, n, err := readMsgpackN(data, 1, 2<<(c-0xdc))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return readMsgpackArray(data, n, int(size), depth)
+	case 0xde, 0xdf:
+		size, n, err := readMsgpackN(data, 1, 2<<(c-0xde))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return readMsgpackMap(data, n, int(size), depth)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return readMsgpackExt(data, 1, 1<<(c-0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		size, n, err := readMsgpackN(data, 1, 1<<(c-0xc7))
+		if err != nil {
+			return Value{}, 0, err
+		}
+		return readMsgpackExt(data, n, int(size))
+	}
+	return Value{}, 0, fmt.Errorf("box: unknown msgpack format 0x%02x", c)
+}
+
+// readMsgpackN reads a big-endian unsigned integer of size bytes at i.
+func readMsgpackN(data []byte, i, size int) (uint64, int, error) {
+	if len(data)-i < size {
+		return 0, 0, errMsgpackShort
+	}
+	var x uint64
+	for _, b := range data[i : i+size] {
+		x = x<<8 | uint64(b)
+	}
+	return x, i + size, nil
+}
+
+func readMsgpackStr(data []byte, i, size int) (Value, int, error) {
+	if size > len(data)-i {
+		return Value{}, 0, errMsgpackShort
+	}
+	return String(string(data[i : i+size])), i + size, nil
+}
+
+func readMsgpackArray(data []byte, i, size, depth int,
+) (Value, int, error) {
+	if depth >= maxMsgpackDepth {
+		return Value{}, 0, errMsgpackDepth
+	}
+	if size > len(data)-i {
+		// every entry takes at least one byte
+		return Value{}, 0, errMsgpackShort
+	}
+	vals := make([]Value, size)
+	for j := range vals {
+		v, n, err := readMsgpack(data[i:], depth+1)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		vals[j] = v
+		i += n
+	}
+	return Slice(vals), i, nil
+}
+
+func readMsgpackMap(data []byte, i, size, depth int,
+) (Value, int, error) {
+	if depth >= maxMsgpackDepth {
+		return Value{}, 0, errMsgpackDepth
+	}
+	if size > (len(data)-i)/2 {
+		return Value{}, 0, errMsgpackShort
+	}
+	keys := make([]Value, size)
+	vals := make([]Value, size)
+	for j := 0; j < size; j++ {
+		k, n, err := readMsgpack(data[i:], depth+1)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		i += n
+		v, n, err := readMsgpack(data[i:], depth+1)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		i += n
+		keys[j], vals[j] = k, v
+	}
+	return Map(keys, vals), i, nil
+}
+
+// readMsgpackExt reads the type and payload of an extension, where i is
+// the position of the type byte.
+func readMsgpackExt(data []byte, i, size int) (Value, int, error) {
+	if len(data)-i < 1+size {
+		return Value{}, 0, errMsgpackShort
+	}
+	typ, p := int8(data[i]), data[i+1:i+1+size]
+	if typ != -1 {
+		return Value{}, 0, fmt.Errorf("box: unknown msgpack extension %d",
+			typ)
+	}
+	var t time.Time
+	switch size {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(p)), 0)
+	case 8:
+		x := binary.BigEndian.Uint64(p)
+		t = time.Unix(int64(x&(1<<34-1)), int64(x>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(p[4:])),
+			int64(binary.BigEndian.Uint32(p)))
+	default:
+		return Value{}, 0, errors.New("box: invalid msgpack timestamp")
+	}
+	return Time(t.UTC()), i + 1 + size, nil
+}
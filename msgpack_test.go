@@ -0,0 +1,154 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type msgpackThing struct{}
+
+func (msgpackThing) AppendMsgpack(dst []byte) []byte {
+	return append(dst, 0xc3)
+}
+
+func TestMsgpack(t *testing.T) {
+	tests := []struct {
+		v    Value
+		want []byte
+	}{
+		{Nil(), []byte{0xc0}},
+		{Bool(false), []byte{0xc2}},
+		{Bool(true), []byte{0xc3}},
+		{Int(0), []byte{0x00}},
+		{Int(127), []byte{0x7f}},
+		{Int(128), []byte{0xcc, 0x80}},
+		{Int(-1), []byte{0xff}},
+		{Int(-32), []byte{0xe0}},
+		{Int(-33), []byte{0xd0, 0xdf}},
+		{Int(-129), []byte{0xd1, 0xff, 0x7f}},
+		{Int(-32769), []byte{0xd2, 0xff, 0xff, 0x7f, 0xff}},
+		{Int(math.MinInt64), []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{Uint(256), []byte{0xcd, 0x01, 0x00}},
+		{Uint(1 << 16), []byte{0xce, 0, 1, 0, 0}},
+		{Uint(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff}},
+		{Float64(1.5), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{String("hi"), []byte{0xa2, 'h', 'i'}},
+		{Bytes([]byte("hi")), []byte{0xc4, 2, 'h', 'i'}},
+		{Rune('a'), []byte{0xa1, 'a'}},
+		{EnumSet(1, 3), []byte{0x92, 1, 3}},
+		{Slice([]Value{Int(1), Nil()}), []byte{0x92, 1, 0xc0}},
+		{Map([]Value{String("a")}, []Value{Int(1)}),
+			[]byte{0x81, 0xa1, 'a', 1}},
+		{Time(time.Unix(1, 0)), []byte{0xd6, 0xff, 0, 0, 0, 1}},
+		{Any(msgpackThing{}), []byte{0xc3}},
+		{Any(Jello{1, 2}), append([]byte{0xa5}, "{1 2}"...)},
+	}
+	for i, tt := range tests {
+		got := tt.v.AppendMsgpack(nil)
+		if !bytes.Equal(got, tt.want) {
+			t.Fatalf("%d: %#v: expected %x, got %x", i, tt.v, tt.want, got)
+		}
+	}
+
+	// round trips
+	long := strings.Repeat("x", 70000)
+	vals := []Value{
+		Nil(), Bool(true), Bool(false), Int(0), Int(-1), Int(-200),
+		Int(-40000), Int(-3000000000), Int(math.MinInt64), Int(100),
+		Int(300), Int(70000), Int(5000000000), Int(math.MaxInt64),
+		Uint(math.MaxUint64), Float64(1.5), Float64(math.Inf(-1)),
+		String(""), String("hello"), String(strings.Repeat("x", 40)),
+		String(strings.Repeat("x", 300)), String(long),
+		Bytes([]byte("hi")), Bytes(make([]byte, 300)),
+		Bytes([]byte(long)),
+		Time(time.Unix(1, 0).UTC()), Time(time.Unix(1, 5).UTC()),
+		Time(time.Unix(1<<35, 5).UTC()), Time(time.Unix(-1, 0).UTC()),
+	}
+	for _, v := range vals {
+		data := v.AppendMsgpack(nil)
+		v2, n, err := ReadMsgpack(data)
+		assert(err == nil && n == len(data))
+		assert(v2.Kind() == v.Kind())
+		if v.IsTime() {
+			assert(v2.Time().Equal(v.Time()))
+		} else {
+			assert(v2.String() == v.String())
+		}
+		for i := 0; i < len(data); i++ {
+			_, _, err := ReadMsgpack(data[:i])
+			assert(err != nil)
+		}
+	}
+
+	// containers
+	many := make([]Value, 20)
+	for i := range many {
+		many[i] = Int(i)
+	}
+	data := Slice(many).AppendMsgpack(nil)
+	assert(data[0] == 0xdc)
+	v, n, err := ReadMsgpack(data)
+	assert(err == nil && n == len(data))
+	list, ok := v.Slice()
+	assert(ok && len(list) == 20 && list[19].Int() == 19)
+	data = Map(many, many).AppendMsgpack(nil)
+	assert(data[0] == 0xde)
+	v, _, err = ReadMsgpack(data)
+	assert(err == nil)
+	keys, vals2, ok := v.Map()
+	assert(ok && len(keys) == 20 && keys[3].Int() == 3 && vals2[3].Int() == 3)
+
+	// decoding other formats
+	v, n, err = ReadMsgpack([]byte{0xca, 0x3f, 0xc0, 0, 0})
+	assert(err == nil && n == 5 && v.Float64() == 1.5)
+	_, _, err = ReadMsgpack([]byte{0xd4, 5, 0})
+	assert(err != nil)
+	_, _, err = ReadMsgpack([]byte{0xc1})
+	assert(err != nil)
+	v, n, err = ReadMsgpack([]byte{0x01, 0x02})
+	assert(err == nil && n == 1 && v.Int() == 1)
+
+	buf := make([]byte, 0, 64)
+	prims := []Value{Nil(), Int(-5), Uint(1 << 40), Float64(1.5),
+		String("hello"), Bytes([]byte("hi")), Bool(true)}
+	assert(allocs(func() {
+		for _, v := range prims {
+			buf = v.AppendMsgpack(buf[:0])
+		}
+	}) == 0)
+}
+
+func TestMsgpackDepth(t *testing.T) {
+	nested := func(prefix []byte, depth int) []byte {
+		data := make([]byte, 0, len(prefix)*depth+1)
+		for i := 0; i < depth; i++ {
+			data = append(data, prefix...)
+		}
+		return append(data, 0xc0)
+	}
+	// nesting up to the limit is read
+	v, n, err := ReadMsgpack(nested([]byte{0x91}, maxMsgpackDepth))
+	assert(err == nil && n == maxMsgpackDepth+1)
+	for i := 0; i < maxMsgpackDepth; i++ {
+		list, ok := v.Slice()
+		assert(ok && len(list) == 1)
+		v = list[0]
+	}
+	assert(v.IsNil())
+
+	// deeper input returns an error rather than overflowing the stack
+	for _, prefix := range [][]byte{{0x91}, {0x81, 0xc0}, {0xdc, 0, 1}} {
+		_, _, err = ReadMsgpack(nested(prefix, maxMsgpackDepth+1))
+		assert(err == errMsgpackDepth)
+	}
+	_, _, err = ReadMsgpack(nested([]byte{0x91}, 50_000_000))
+	assert(err == errMsgpackDepth)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+)
+
+// Number is a numeric value in its most faithful form. Integers are kept as
+// signed or unsigned 64-bit integers, and floats as float64, so that no
+// precision is lost until the caller picks a representation.
+type Number struct {
+	bits uint64
+	kind byte // 'i', 'u', or 'f'
+}
+
+// Number returns the value as a Number, and false when the value is not a
+// number or a string that parses as one.
+//
+// Ints, durations, and runes become signed integers. Uints, custom bits,
+// and IDs become unsigned integers, which keeps values above math.MaxInt64,
+// up to math.MaxUint64, exact. Floats and percents stay floats. Strings and
+// byte slices are parsed as a signed integer, then an unsigned integer, and
+// finally a float.
+func (v Value) Number() (Number, bool) {
+	v = v.unwrap()
+	switch v.ptr {
+	case int64Type, durationType, runeType:
+		return Number{v.ext, 'i'}, true
+	case uint64Type, custBitsType, idType:
+		return Number{v.ext, 'u'}, true
+	case float64Type, percentType:
+		return Number{v.ext, 'f'}, true
+	}
+	if s, ok := v.numericString(); ok {
+		if x, err := parseInt(s); err == nil {
+			return Number{uint64(x), 'i'}, true
+		}
+		if x, err := parseUint(s); err == nil {
+			return Number{x, 'u'}, true
+		}
+		if x, err := parseFloat(s); err == nil {
+			return Number{math.Float64bits(x), 'f'}, true
+		}
+		return Number{}, false
+	}
+	if !v.isPrim() {
+		if vf, ok := v.assertNonPrimAny().(Float64er); ok {
+			return Number{math.Float64bits(vf.Float64()), 'f'}, true
+		}
+	}
+	return Number{}, false
+}
+
+// IsInt returns true if the number is a signed or unsigned integer.
+func (n Number) IsInt() bool {
+	return n.kind == 'i' || n.kind == 'u'
+}
+
+// IsFloat returns true if the number is a float.
+func (n Number) IsFloat() bool {
+	return n.kind == 'f'
+}
+
+// Int64 returns the number as an int64, and false when it cannot be
+// represented exactly, such as an unsigned integer larger than
+// math.MaxInt64 or a float with a fractional part.
+func (n Number) Int64() (int64, bool) {
+	switch n.kind {
+	case 'i':
+		return int64(n.bits), true
+	case 'u':
+		return int64(n.bits), n.bits <= math.MaxInt64
+	case 'f':
+		return ftoiExact(math.Float64frombits(n.bits))
+	}
+	return 0, false
+}
+
+// Uint64 returns the number as a uint64, and false when it cannot be
+// represented exactly, such as a negative integer or a float with a
+// fractional part.
+func (n Number) Uint64() (uint64, bool) {
+	switch n.kind {
+	case 'i':
+		return n.bits, int64(n.bits) >= 0
+	case 'u':
+		return n.bits, true
+	case 'f':
+		return ftouExact(math.Float64frombits(n.bits))
+	}
+	return 0, false
+}
+
+// Float64 returns the number as a float64. Integers with a magnitude above
+// 2^53 are rounded to the nearest float64, such that both math.MaxInt64 and
+// math.MaxUint64 become powers of two. Use Int64 or Uint64 for exact values.
+func (n Number) Float64() float64 {
+	switch n.kind {
+	case 'i':
+		return float64(int64(n.bits))
+	case 'u':
+		return float64(n.bits)
+	case 'f':
+		return math.Float64frombits(n.bits)
+	}
+	return 0
+}
+
+// String returns the number formatted in base 10. Floats are formatted like
+// a boxed Float64.
+func (n Number) String() string {
+	switch n.kind {
+	case 'i':
+		return strconv.FormatInt(int64(n.bits), 10)
+	case 'u':
+		return strconv.FormatUint(n.bits, 10)
+	case 'f':
+		return Float64(math.Float64frombits(n.bits)).String()
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNumber(t *testing.T) {
+	n, ok := Uint64(math.MaxUint64).Number()
+	assert(ok && n.IsInt() && !n.IsFloat())
+	u, ok := n.Uint64()
+	assert(ok && u == math.MaxUint64)
+	_, ok = n.Int64()
+	assert(!ok)
+	assert(n.Float64() == 1<<64)
+	assert(n.String() == "18446744073709551615")
+
+	n, ok = Int64(math.MaxInt64).Number()
+	assert(ok && n.IsInt())
+	i, ok := n.Int64()
+	assert(ok && i == math.MaxInt64)
+	u, ok = n.Uint64()
+	assert(ok && u == math.MaxInt64)
+	assert(n.Float64() == 1<<63)
+	assert(n.String() == "9223372036854775807")
+
+	n, ok = Uint64(1 << 63).Number()
+	assert(ok && n.IsInt())
+	_, ok = n.Int64()
+	assert(!ok)
+	u, _ = n.Uint64()
+	assert(u == 1<<63)
+
+	n, ok = Int64(-1).Number()
+	assert(ok)
+	_, ok = n.Uint64()
+	assert(!ok)
+
+	n, ok = Float64(2.5).Number()
+	assert(ok && n.IsFloat() && n.Float64() == 2.5)
+	_, ok = n.Int64()
+	assert(!ok)
+	n, _ = Float64(2).Number()
+	i, ok = n.Int64()
+	assert(ok && i == 2)
+	n, _ = Float64(1 << 64).Number()
+	_, ok = n.Uint64()
+	assert(!ok)
+
+	n, ok = String("18446744073709551615").Number()
+	assert(ok && n.IsInt() && n.String() == "18446744073709551615")
+	n, ok = String("-3").Number()
+	assert(ok && n.IsInt() && n.String() == "-3")
+	n, ok = Bytes([]byte("1.5")).Number()
+	assert(ok && n.IsFloat() && n.String() == "1.5")
+	n, ok = Duration(time.Second).Number()
+	assert(ok && n.IsInt() && n.Float64() == 1e9)
+	n, ok = Percent(0.5).Number()
+	assert(ok && n.IsFloat() && n.Float64() == 0.5)
+
+	for _, v := range []Value{Nil(), Bool(true), String("hello"),
+		Any(Jello{})} {
+		_, ok := v.Number()
+		assert(!ok)
+	}
+	var z Number
+	assert(!z.IsInt() && !z.IsFloat() && z.String() == "")
+	_, ok = z.Int64()
+	assert(!ok)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseInt parses a string that is converted to an integer. The 0x, 0b,
+// and 0o prefixes select the base, as with strconv.ParseInt using base 0,
+// and a leading sign is allowed. Numbers without a prefix are always
+// decimal, even with leading zeros, such that "010" is 10 and "08" is 8.
+// Underscores may separate digits, as in Go source, such that "1_000" is
+// 1000, while "_1", "1_", and "1__0" are invalid. Surrounding whitespace is
+// ignored.
+func parseInt(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if hasBasePrefix(s) {
+		return strconv.ParseInt(s, 0, 64)
+	}
+	s, err := stripUnderscores(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseUint is like parseInt for a uint64. A leading "+" is allowed.
+func parseUint(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > 1 && s[0] == '+' {
+		s = s[1:]
+	}
+	if hasBasePrefix(s) {
+		return strconv.ParseUint(s, 0, 64)
+	}
+	s, err := stripUnderscores(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// parseIntLoose is like parseInt, but also reads a finite float, which is
+// truncated toward zero and clamped to the int64 range, such that "2.9" is
+// 2, "1e3" is 1000, and "1e30" is math.MaxInt64. NaN and the infinities are
+// not read.
+func parseIntLoose(s string) (int64, bool) {
+	if x, err := parseInt(s); err == nil {
+		return x, true
+	}
+	if f, err := parseFloat(s); err == nil && !math.IsInf(f, 0) && f == f {
+		return ftoi(f), true
+	}
+	return 0, false
+}
+
+// parseUintLoose is like parseIntLoose for a uint64, where negative floats
+// are 0.
+func parseUintLoose(s string) (uint64, bool) {
+	if x, err := parseUint(s); err == nil {
+		return x, true
+	}
+	if f, err := parseFloat(s); err == nil && !math.IsInf(f, 0) && f == f {
+		return ftou(f), true
+	}
+	return 0, false
+}
+
+// parseFloat parses a string that is converted to a float. It accepts
+// everything strconv.ParseFloat does, including hex floats such as
+// "0x1p-2", and also the integers accepted by parseInt and parseUint,
+// such as "0xff" and "1_000". Underscores are only accepted in a decimal
+// number without a fraction or exponent, such that "3_0.5" is invalid,
+// even though strconv.ParseFloat reads it as 30.5.
+func parseFloat(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	underscores := !hasBasePrefix(s) && strings.IndexByte(s, '_') != -1
+	var f float64
+	err := strconv.ErrSyntax
+	if !underscores {
+		f, err = strconv.ParseFloat(s, 64)
+	}
+	if err != nil && (underscores || hasBasePrefix(s)) {
+		if x, err := parseInt(s); err == nil {
+			return float64(x), nil
+		}
+		if x, err := parseUint(s); err == nil {
+			return float64(x), nil
+		}
+	}
+	return f, err
+}
+
+// hasBasePrefix returns true if s, after an optional sign, starts with
+// 0x, 0b, or 0o.
+func hasBasePrefix(s string) bool {
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if len(s) < 2 || s[0] != '0' {
+		return false
+	}
+	switch s[1] {
+	case 'x', 'X', 'b', 'B', 'o', 'O':
+		return true
+	}
+	return false
+}
+
+// stripUnderscores removes the underscores from a decimal number, and
+// returns an error when an underscore is not between two digits.
+func stripUnderscores(s string) (string, error) {
+	if strings.IndexByte(s, '_') == -1 {
+		return s, nil
+	}
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' {
+			if !isDigit(i-1) || !isDigit(i+1) {
+				return "", strconv.ErrSyntax
+			}
+			continue
+		}
+		b = append(b, s[i])
+	}
+	return string(b), nil
+}
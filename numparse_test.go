@@ -0,0 +1,197 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBasePrefixes(t *testing.T) {
+	for _, c := range []struct {
+		s string
+		x int64
+	}{
+		{"0xff", 255}, {"0XFF", 255}, {"-0xff", -255}, {"+0xff", 255},
+		{"0b101", 5}, {"0o17", 15}, {"0O17", 15}, {"0x_ff", 255},
+		{"+42", 42}, {"42", 42}, {"-42", -42},
+		{"08", 8}, {"010", 10}, {"-007", -7}, {"0", 0},
+		{"0x", 0}, {"0b2", 0}, {"0o8", 0}, {"0xg", 0}, {"hello", 0},
+		{"0x8000000000000000", math.MaxInt64},
+	} {
+		if got := String(c.s).Int64(); got != c.x {
+			t.Fatalf("%q: expected %d, got %d", c.s, c.x, got)
+		}
+		assert(Bytes([]byte(c.s)).Int64() == c.x)
+	}
+	for _, c := range []struct {
+		s string
+		x uint64
+	}{
+		{"0xff", 255}, {"+0xff", 255}, {"0b11", 3}, {"0o10", 8},
+		{"+42", 42}, {"08", 8}, {"010", 10},
+		{"0xffffffffffffffff", math.MaxUint64},
+		{"-0xff", 0}, {"-1", 0}, {"+", 0}, {"++1", 0},
+	} {
+		if got := String(c.s).Uint64(); got != c.x {
+			t.Fatalf("%q: expected %d, got %d", c.s, c.x, got)
+		}
+	}
+	for _, c := range []struct {
+		s string
+		f float64
+	}{
+		{"0x1p-2", 0.25}, {"0x1.8p1", 3}, {"-0x1p4", -16}, {"0xff", 255},
+		{"0b101", 5}, {"0o17", 15}, {"+1.5", 1.5}, {"010", 10},
+		{"0xffffffffffffffff", math.MaxUint64},
+	} {
+		if got := String(c.s).Float64(); got != c.f {
+			t.Fatalf("%q: expected %v, got %v", c.s, c.f, got)
+		}
+	}
+	assert(math.IsNaN(String("0xg").Float64()))
+
+	x, ok := String("0x10").AsInt64()
+	assert(ok && x == 16)
+	u, ok := String("+0b1").AsUint64()
+	assert(ok && u == 1)
+	f, ok := String("0x1p-1").AsFloat64()
+	assert(ok && f == 0.5)
+	_, ok = String("0x").AsInt64()
+	assert(!ok)
+	i, err := String("0o777").Int64E()
+	assert(err == nil && i == 0o777)
+	n, ok := String("0xff").Number()
+	assert(ok && n.IsInt() && n.String() == "255")
+}
+
+func TestUnderscores(t *testing.T) {
+	for _, v := range []Value{
+		String("1_000_000"), Bytes([]byte("1_000_000")),
+		String("+1_000_000"), String("1_0_0_0_0_0_0"),
+	} {
+		assert(v.Int64() == 1e6 && v.Uint64() == 1e6 && v.Float64() == 1e6)
+		x, ok := v.AsInt64()
+		assert(ok && x == 1e6)
+		u, ok := v.AsUint64()
+		assert(ok && u == 1e6)
+		f, ok := v.AsFloat64()
+		assert(ok && f == 1e6)
+		_, err := v.Int64E()
+		assert(err == nil)
+	}
+	assert(String("-1_000").Int64() == -1000)
+	assert(String("0x_ff_ff").Int64() == 0xffff)
+	assert(String("0b_1_0").Uint64() == 2)
+	assert(String("0_10").Int64() == 10)
+
+	for _, s := range []string{
+		"_1000", "1000_", "1__000", "-_1000", "+_1", "3_0.5", "1_000.5",
+		"1e1_0", "_", "1_a", "0x__ff", "0xff_",
+	} {
+		for _, v := range []Value{String(s), Bytes([]byte(s))} {
+			assert(v.Int64() == 0 && v.Uint64() == 0)
+			assert(math.IsNaN(v.Float64()))
+			_, ok := v.AsInt64()
+			assert(!ok)
+			_, ok = v.AsUint64()
+			assert(!ok)
+			_, ok = v.AsFloat64()
+			assert(!ok)
+			_, err := v.Float64E()
+			assert(err != nil)
+		}
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	check := func() {
+		for _, s := range []string{" 42 ", "\t42\n", "42 ", " +42", " 42 "} {
+			for _, v := range []Value{String(s), Bytes([]byte(s))} {
+				assert(v.Int64() == 42 && v.Uint64() == 42 && v.Float64() == 42)
+				x, ok := v.AsInt64()
+				assert(ok && x == 42)
+				f, ok := v.AsFloat64()
+				assert(ok && f == 42)
+				n, ok := v.Number()
+				assert(ok && n.String() == "42")
+			}
+		}
+		for _, s := range []string{" true \n", "\t1", " True"} {
+			for _, v := range []Value{String(s), Bytes([]byte(s))} {
+				assert(v.Bool() && v.BoolLoose())
+				x, ok := v.AsBool()
+				assert(ok && x)
+			}
+		}
+		assert(String(" yes ").BoolLoose() && !String(" off\n").BoolLoose())
+		assert(Bytes([]byte(" on ")).BoolLoose())
+		assert(String(" 0x10 ").Int64() == 16 && String(" 1_000 ").Int64() == 1000)
+		assert(String(" 1.5 ").Float64() == 1.5)
+		for _, s := range []string{"4 2", " ", "", "- 1", "1 .5", "tr ue"} {
+			for _, v := range []Value{String(s), Bytes([]byte(s))} {
+				assert(v.Int64() == 0 && v.Uint64() == 0 && !v.Bool())
+				assert(math.IsNaN(v.Float64()))
+				_, ok := v.AsInt64()
+				assert(!ok)
+				_, ok = v.AsBool()
+				assert(!ok)
+			}
+		}
+	}
+	check()
+	forceIfaceStrs = true
+	check()
+	forceIfaceStrs = false
+
+	// trimming does not copy, so padding adds no allocations
+	v, w := String("  123\t"), String("123")
+	assert(allocs(func() { _ = v.Int64() }) == allocs(func() { _ = w.Int64() }))
+	assert(allocs(func() { _ = v.Float64() }) ==
+		allocs(func() { _ = w.Float64() }))
+	assert(allocs(func() { _, _ = v.AsInt64() }) == 0)
+	v = String(" true ")
+	assert(allocs(func() { _, _ = v.AsBool() }) == 0)
+	assert(allocs(func() { _ = v.BoolLoose() }) == 0)
+}
+
+func TestFloatStringsAsInts(t *testing.T) {
+	for _, c := range []struct {
+		s string
+		i int64
+		u uint64
+	}{
+		{"3.0", 3, 3}, {"2.9", 2, 2}, {"-2.9", -2, 0}, {"1e3", 1000, 1000},
+		{"1E3", 1000, 1000}, {"1.5e1", 15, 15}, {"-1e3", -1000, 0},
+		{"2.5e-1", 0, 0}, {"+7.0", 7, 7}, {" 4.0 ", 4, 4}, {"0x1p4", 16, 16},
+		{"1e19", math.MaxInt64, 1e19}, {"-1e19", math.MinInt64, 0},
+		{"1e30", math.MaxInt64, math.MaxUint64},
+		{"9223372036854775808", math.MaxInt64, 1 << 63},
+		{"18446744073709551616", math.MaxInt64, math.MaxUint64},
+		{"NaN", 0, 0}, {"Inf", 0, 0}, {"-Infinity", 0, 0},
+		{"hello", 0, 0}, {"1.2.3", 0, 0}, {"1e", 0, 0}, {".", 0, 0},
+	} {
+		for _, v := range []Value{String(c.s), Bytes([]byte(c.s))} {
+			if v.Int64() != c.i || v.Uint64() != c.u {
+				t.Fatalf("%q: expected %d %d, got %d %d", c.s, c.i, c.u,
+					v.Int64(), v.Uint64())
+			}
+		}
+	}
+	assert(String("3.9").Int() == 3 && String("300.5").Int8() == 44)
+	assert(String("3.9").Convert(KindInt).Int() == 3)
+
+	// the strict variants only accept exact integers
+	x, ok := String("3.0").AsInt64()
+	assert(ok && x == 3)
+	x, ok = String("1e3").AsInt64()
+	assert(ok && x == 1000)
+	_, ok = String("2.9").AsInt64()
+	assert(!ok)
+	_, err := String("2.9").Int64E()
+	assert(err != nil)
+	_, err = String("hello").Uint64E()
+	assert(err != nil)
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "unsafe"
+
+// Float64Slice boxes a []float64 without copying it. Like box.Bytes, the
+// slice header is stored inline and boxing does not allocate, unless the
+// slice is very large or has a lot of spare capacity.
+// The boxed value shares its backing array with s, so changes to the
+// elements of s are seen through the box and the other way around.
+// Like a nil byte slice, a nil s is not Nil, and comes back out as nil.
+func Float64Slice(s []float64) Value {
+	n, c := uint64(len(s)), uint64(cap(s))
+	if forceIfaceStrs || n > maxLen || c-n > maxCap {
+		return toIface(s)
+	}
+	return Value{
+		ext: n<<32 | (c-n)<<8 | ptrFloat64s,
+		ptr: slicePtr(s == nil, (*bface)(unsafe.Pointer(&s)).ptr),
+	}
+}
+
+// Float64Slice returns the []float64 from a value created by
+// box.Float64Slice, or a boxed []float64. The slice is returned without
+// copying or reflection, and shares its backing array with the slice that
+// was boxed. Returns false for any other value.
+func (v Value) Float64Slice() ([]float64, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil, false
+	}
+	if v.ext&0xFF == ptrFloat64s {
+		return v.assertFloat64s(), true
+	}
+	s, ok := v.assertNonPrimAny().([]float64)
+	return s, ok
+}
+
+// IsFloat64Slice returns true if the boxed value is a []float64.
+func (v Value) IsFloat64Slice() bool {
+	_, ok := v.Float64Slice()
+	return ok
+}
+
+// Int64Slice boxes a []int64 without copying it, like Float64Slice.
+func Int64Slice(s []int64) Value {
+	n, c := uint64(len(s)), uint64(cap(s))
+	if forceIfaceStrs || n > maxLen || c-n > maxCap {
+		return toIface(s)
+	}
+	return Value{
+		ext: n<<32 | (c-n)<<8 | ptrInt64s,
+		ptr: slicePtr(s == nil, (*bface)(unsafe.Pointer(&s)).ptr),
+	}
+}
+
+// Int64Slice returns the []int64 from a value created by box.Int64Slice,
+// or a boxed []int64, like Float64Slice.
+func (v Value) Int64Slice() ([]int64, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil, false
+	}
+	if v.ext&0xFF == ptrInt64s {
+		return v.assertInt64s(), true
+	}
+	s, ok := v.assertNonPrimAny().([]int64)
+	return s, ok
+}
+
+// IsInt64Slice returns true if the boxed value is a []int64.
+func (v Value) IsInt64Slice() bool {
+	_, ok := v.Int64Slice()
+	return ok
+}
+
+// slicePtr returns the pointer to store for a slice with the data pointer
+// p, using the same sentinels as for byte slices.
+func slicePtr(isNil bool, p unsafe.Pointer) unsafe.Pointer {
+	if isNil {
+		return unsafe.Pointer(&nilBytesData)
+	}
+	return nonNilPtr(p)
+}
+
+func (v Value) sliceHeader() bface {
+	if v.ptr == unsafe.Pointer(&nilBytesData) {
+		return bface{}
+	}
+	n := int(v.ext >> 32)
+	return bface{ptr: v.ptr, len: n, cap: n + int((v.ext>>8)&maxCap)}
+}
+
+func (v Value) assertFloat64s() []float64 {
+	h := v.sliceHeader()
+	return *(*[]float64)(unsafe.Pointer(&h))
+}
+
+func (v Value) assertInt64s() []int64 {
+	h := v.sliceHeader()
+	return *(*[]int64)(unsafe.Pointer(&h))
+}
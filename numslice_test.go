@@ -0,0 +1,84 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFloat64Slice(t *testing.T) {
+	s := []float64{1.5, 2.5, 3.5}
+	v := Float64Slice(s)
+	assert(v.IsFloat64Slice() && !v.IsInt64Slice() && !v.IsBytes())
+	s2, ok := v.Float64Slice()
+	assert(ok && len(s2) == 3 && cap(s2) == cap(s))
+	// aliases the input
+	assert(&s2[0] == &s[0])
+	s[1] = 9
+	assert(s2[1] == 9)
+	assert(allocs(func() { v = Float64Slice(s) }) == 0)
+	assert(allocs(func() { s2, _ = v.Float64Slice() }) == 0)
+
+	assert(v.String() == "[1.5 9 3.5]")
+	assert(reflect.DeepEqual(v.Any(), s))
+	assert(v.Type() == reflect.TypeOf(s))
+	assert(v.Kind() == KindAny && v.Len() == 3)
+	data, err := json.Marshal(v)
+	assert(err == nil && string(data) == "[1.5,9,3.5]")
+	assert(v.EqualIdentity(Float64Slice(s)))
+	assert(!v.EqualIdentity(Float64Slice(s[:2])))
+
+	v = Any(s)
+	s2, ok = v.Float64Slice()
+	assert(ok && &s2[0] == &s[0])
+	v = Float64Slice(nil)
+	s2, ok = v.Float64Slice()
+	assert(ok && s2 == nil && !v.IsNil() && v.Len() == 0)
+	assert(v.Any().([]float64) == nil)
+	s2, ok = Float64Slice([]float64{}).Float64Slice()
+	assert(ok && s2 != nil && len(s2) == 0)
+
+	forceIfaceStrs = true
+	v = Float64Slice(s)
+	s2, ok = v.Float64Slice()
+	assert(ok && &s2[0] == &s[0])
+	forceIfaceStrs = false
+
+	_, ok = Int(1).Float64Slice()
+	assert(!ok)
+	_, ok = Any([]float32{1}).Float64Slice()
+	assert(!ok)
+}
+
+func TestInt64Slice(t *testing.T) {
+	s := make([]int64, 2, 10)
+	s[0], s[1] = -1, 2
+	v := Int64Slice(s)
+	assert(v.IsInt64Slice() && !v.IsFloat64Slice())
+	s2, ok := v.Int64Slice()
+	assert(ok && len(s2) == 2 && cap(s2) == 10 && &s2[0] == &s[0])
+	s2[0] = 7
+	assert(s[0] == 7)
+	s2 = append(s2, 3)
+	assert(s[:3][2] == 3)
+	assert(allocs(func() { v = Int64Slice(s) }) == 0)
+	assert(v.String() == "[7 2]")
+	assert(reflect.DeepEqual(v.Any(), []int64{7, 2}))
+
+	v = Any(s)
+	assert(v.IsInt64Slice())
+	c := v.DeepClone()
+	s2, _ = c.Int64Slice()
+	assert(len(s2) == 2 && &s2[0] != &s[0] && s2[0] == 7)
+
+	v = Int64Slice(nil)
+	s2, ok = v.Int64Slice()
+	assert(ok && s2 == nil && !v.IsNil())
+	assert(!v.EqualIdentity(Int64Slice([]int64{})))
+	_, ok = Float64Slice(nil).Int64Slice()
+	assert(!ok)
+}
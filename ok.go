@@ -0,0 +1,93 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "math"
+
+// StringOk returns the boxed string.
+// Returns false, without converting, when the value is not a string.
+func (v Value) StringOk() (string, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return "", false
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return v.assertString(), true
+	case ptrBytes:
+		return "", false
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string:
+		return vf, true
+	case *taggedString:
+		return vf.str, true
+	}
+	return "", false
+}
+
+// BytesOk returns the boxed byte slice.
+// Returns false, without converting, when the value is not a byte slice.
+func (v Value) BytesOk() ([]byte, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil, false
+	}
+	switch v.ext & 0xFF {
+	case ptrBytes:
+		return v.assertBytes(), true
+	case ptrString:
+		return nil, false
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case []byte:
+		return vf, true
+	case *lazyBytes:
+		return vf.bytes(), true
+	case *taggedBytes:
+		return vf.bytes, true
+	}
+	return nil, false
+}
+
+// Int64Ok returns the boxed int64.
+// Returns false, without converting, when IsInt() is false.
+func (v Value) Int64Ok() (int64, bool) {
+	v = v.unwrap()
+	if v.ptr != int64Type {
+		return 0, false
+	}
+	return int64(v.ext), true
+}
+
+// Uint64Ok returns the boxed uint64.
+// Returns false, without converting, when IsUint() is false.
+func (v Value) Uint64Ok() (uint64, bool) {
+	v = v.unwrap()
+	if v.ptr != uint64Type {
+		return 0, false
+	}
+	return v.ext, true
+}
+
+// Float64Ok returns the boxed float64.
+// Returns false, without converting, when IsFloat() is false.
+func (v Value) Float64Ok() (float64, bool) {
+	v = v.unwrap()
+	if v.ptr != float64Type {
+		return 0, false
+	}
+	return math.Float64frombits(v.ext), true
+}
+
+// BoolOk returns the boxed bool.
+// Returns false, without converting, when IsBool() is false.
+func (v Value) BoolOk() (bool, bool) {
+	v = v.unwrap()
+	if v.ptr != boolType {
+		return false, false
+	}
+	return v.ext != 0, true
+}
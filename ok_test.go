@@ -0,0 +1,74 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "testing"
+
+func TestOk(t *testing.T) {
+	s, ok := String("hello").StringOk()
+	assert(ok && s == "hello")
+	s, ok = StringWithTag("hello", 1).StringOk()
+	assert(ok && s == "hello")
+	_, ok = Bytes([]byte("hello")).StringOk()
+	assert(!ok)
+	_, ok = Int(1).StringOk()
+	assert(!ok)
+	_, ok = Nil().StringOk()
+	assert(!ok)
+
+	b, ok := Bytes([]byte("hello")).BytesOk()
+	assert(ok && string(b) == "hello")
+	_, ok = String("hello").BytesOk()
+	assert(!ok)
+
+	i, ok := Int(-5).Int64Ok()
+	assert(ok && i == -5)
+	_, ok = String("5").Int64Ok()
+	assert(!ok)
+	_, ok = Uint(5).Int64Ok()
+	assert(!ok)
+	_, ok = Duration(5).Int64Ok()
+	assert(!ok)
+
+	u, ok := Uint(5).Uint64Ok()
+	assert(ok && u == 5)
+	_, ok = Int(5).Uint64Ok()
+	assert(!ok)
+
+	f, ok := Float64(1.5).Float64Ok()
+	assert(ok && f == 1.5)
+	_, ok = String("1.5").Float64Ok()
+	assert(!ok)
+	_, ok = Int(1).Float64Ok()
+	assert(!ok)
+
+	tf, ok := Bool(false).BoolOk()
+	assert(ok && !tf)
+	_, ok = String("true").BoolOk()
+	assert(!ok)
+	_, ok = Int(1).BoolOk()
+	assert(!ok)
+
+	forceIfaceStrs = true
+	s, ok = String("hello").StringOk()
+	assert(ok && s == "hello")
+	s, ok = StringWithTag("hello", 1).StringOk()
+	assert(ok && s == "hello")
+	b, ok = Bytes([]byte("hello")).BytesOk()
+	assert(ok && string(b) == "hello")
+	_, ok = Bytes([]byte("hello")).StringOk()
+	assert(!ok)
+	forceIfaceStrs = false
+	_, ok = Any(Jello{}).StringOk()
+	assert(!ok)
+	_, ok = Any(Jello{}).BytesOk()
+	assert(!ok)
+
+	iv, sv, bv := Int(5), String("hello"), Bytes([]byte("hello"))
+	assert(allocs(func() { i, ok = iv.Int64Ok() }) == 0)
+	assert(allocs(func() { s, ok = sv.StringOk() }) == 0)
+	assert(allocs(func() { b, ok = bv.BytesOk() }) == 0)
+	assert(allocs(func() { f, ok = iv.Float64Ok() }) == 0)
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// Int64Or returns the value as an int64, or def when the value is nil or
+// cannot be converted exactly.
+//
+// Numbers convert when they fit without loss, so 5.0 returns 5 while 5.5
+// and math.MaxUint64 return def. Strings are parsed, so "0" returns 0 while
+// "" and "hello" return def. Bools return 0 or 1.
+func (v Value) Int64Or(def int64) int64 {
+	if x, ok := v.AsInt64(); ok {
+		return x
+	}
+	return def
+}
+
+// Float64Or returns the value as a float64, or def when the value is nil or
+// is not a number. Strings are parsed, so "0" returns 0 while "" and
+// "hello" return def. A boxed NaN is returned as is.
+func (v Value) Float64Or(def float64) float64 {
+	if x, ok := v.AsFloat64(); ok {
+		return x
+	}
+	return def
+}
+
+// BoolOr returns the value as a bool, or def when the value is nil or
+// cannot be converted. Numbers are true when not zero, and strings are
+// parsed like AsBool, so "0" returns false while "" and "hello" return
+// def.
+func (v Value) BoolOr(def bool) bool {
+	if x, ok := v.AsBool(); ok {
+		return x
+	}
+	return def
+}
+
+// StringOr returns the value as a string, or def when the value is nil.
+// Any other value has a string form, so "0" returns "0" and an empty
+// string returns "".
+func (v Value) StringOr(def string) string {
+	if v.IsNil() {
+		return def
+	}
+	return v.String()
+}
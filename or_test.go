@@ -0,0 +1,48 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOr(t *testing.T) {
+	assert(String("hello").Int64Or(7) == 7)
+	assert(String("hello").Int64() == 0)
+	assert(String("0").Int64Or(7) == 0)
+	assert(String("").Int64Or(7) == 7)
+	assert(String("12").Int64Or(7) == 12)
+	assert(Bytes([]byte("-3")).Int64Or(7) == -3)
+	assert(Nil().Int64Or(7) == 7)
+	assert(Int(0).Int64Or(7) == 0)
+	assert(Float64(5).Int64Or(7) == 5)
+	assert(Float64(5.5).Int64Or(7) == 7)
+	assert(Uint64(math.MaxUint64).Int64Or(7) == 7)
+	assert(Bool(true).Int64Or(7) == 1)
+	assert(Any(Jello{}).Int64Or(7) == 7)
+
+	assert(String("hello").Float64Or(1.5) == 1.5)
+	assert(String("0").Float64Or(1.5) == 0)
+	assert(String("").Float64Or(1.5) == 1.5)
+	assert(String("2.25").Float64Or(1.5) == 2.25)
+	assert(Nil().Float64Or(1.5) == 1.5)
+	assert(Int(3).Float64Or(1.5) == 3)
+	assert(math.IsNaN(Float64(math.NaN()).Float64Or(1.5)))
+
+	assert(String("hello").BoolOr(true))
+	assert(!String("0").BoolOr(true))
+	assert(String("").BoolOr(true))
+	assert(String("true").BoolOr(false))
+	assert(Nil().BoolOr(true))
+	assert(!Int(0).BoolOr(true))
+	assert(Int(2).BoolOr(false))
+
+	assert(String("hello").StringOr("x") == "hello")
+	assert(String("0").StringOr("x") == "0")
+	assert(String("").StringOr("x") == "")
+	assert(Nil().StringOr("x") == "x")
+	assert(Int(0).StringOr("x") == "0")
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "strings"
+
+// path is a dotted path and its segments, which are split once.
+type path struct {
+	raw  string
+	segs []string
+}
+
+func (p *path) String() string {
+	return p.raw
+}
+
+// Path boxes a dotted path, such as "server.http.port". The path is split
+// into its segments once, when boxed, so that Segments does not need to
+// split it again. String() returns the path as given. An empty path has no
+// segments.
+func Path(p string) Value {
+	var segs []string
+	if p != "" {
+		segs = strings.Split(p, ".")
+	}
+	return toIface(&path{raw: p, segs: segs})
+}
+
+// Segments returns the segments of a value created by box.Path, such as
+// "a", "b", and "c" for "a.b.c". The returned slice is shared by every call
+// and must not be modified. Returns nil for any other value.
+func (v Value) Segments() []string {
+	v = v.unwrap()
+	if v.isPrim() {
+		return nil
+	}
+	if p, ok := v.assertNonPrimAny().(*path); ok {
+		return p.segs
+	}
+	return nil
+}
+
+// IsPath returns true if the boxed value was created using box.Path.
+func (v Value) IsPath() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(*path)
+	return ok
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	v := Path("server.http.port")
+	assert(v.IsPath() && !v.IsString())
+	assert(v.String() == "server.http.port")
+	segs := v.Segments()
+	assert(reflect.DeepEqual(segs, []string{"server", "http", "port"}))
+
+	// the segments are split once and shared
+	assert(&v.Segments()[0] == &segs[0])
+	assert(allocs(func() { segs = v.Segments() }) == 0)
+
+	assert(Path("a").Segments()[0] == "a")
+	assert(Path("").Segments() == nil && Path("").IsPath())
+	assert(reflect.DeepEqual(Path("a..b").Segments(), []string{"a", "", "b"}))
+	assert(String("a.b").Segments() == nil && !String("a.b").IsPath())
+	assert(Int(1).Segments() == nil && !Int(1).IsPath())
+
+	data, err := json.Marshal(v)
+	assert(err == nil && string(data) == `"server.http.port"`)
+	forceIfacePtrs = true
+	v = Path("a.b")
+	assert(v.IsPath() && len(v.Segments()) == 2)
+	forceIfacePtrs = false
+}
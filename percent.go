@@ -0,0 +1,33 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+)
+
+// Percent boxes a percentage.
+// The value is a fraction, where 1.0 is one hundred percent. Float64()
+// returns the fraction as-is and String() renders it as a percentage with
+// one decimal place, such as Percent(0.42).String() == "42.0%".
+func Percent(f float64) Value {
+	return Value{math.Float64bits(f), percentType}
+}
+
+// IsPercent returns true if the boxed value was created using box.Percent.
+func (v Value) IsPercent() bool { return v.unwrap().ptr == percentType }
+
+// PercentString returns the value rendered as a percentage with prec decimal
+// places. When clamp is true the percentage is limited to the range 0 to
+// 100. Values that are not a box.Percent are treated as a fraction using
+// Float64().
+func (v Value) PercentString(prec int, clamp bool) string {
+	p := v.Float64() * 100
+	if clamp {
+		p = math.Max(0, math.Min(100, p))
+	}
+	return strconv.FormatFloat(p, 'f', prec, 64) + "%"
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "testing"
+
+func TestPercent(t *testing.T) {
+	v := Percent(0.42)
+	assert(v.IsPercent())
+	assert(!v.IsFloat())
+	assert(v.Float64() == 0.42)
+	assert(v.Any().(float64) == 0.42)
+	assert(v.String() == "42.0%")
+	assert(string(v.Bytes()) == "42.0%")
+	assert(v.PercentString(0, false) == "42%")
+	assert(v.PercentString(3, false) == "42.000%")
+	assert(Percent(1.5).String() == "150.0%")
+	assert(Percent(1.5).PercentString(1, true) == "100.0%")
+	assert(Percent(-0.25).PercentString(1, true) == "0.0%")
+	assert(Percent(-0.25).String() == "-25.0%")
+	assert(Percent(2).Int() == 2)
+	assert(Percent(0).Bool() == false)
+	assert(Float64(0.5).PercentString(1, false) == "50.0%")
+	assert(!Float64(0.5).IsPercent())
+
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var v2 Value
+	assert(v2.UnmarshalBinary(data) == nil)
+	assert(v2.IsPercent() && v2.Float64() == 0.42)
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// quantity is a number with a unit of measurement.
+type quantity struct {
+	value float64
+	unit  string
+}
+
+func (q *quantity) String() string {
+	s := strconv.FormatFloat(q.value, 'f', -1, 64)
+	if q.unit == "" {
+		return s
+	}
+	return s + " " + q.unit
+}
+
+func (q *quantity) Float64() float64 { return q.value }
+
+func (q *quantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Value float64 `json:"value"`
+		Unit  string  `json:"unit"`
+	}{q.value, q.unit})
+}
+
+// Quantity boxes a number with a unit of measurement, such as
+// Quantity(3, "m"). String() renders the number followed by the unit,
+// such as "3 m", and Float64() returns the number. The JSON encoding is an
+// object with the number and unit, such as {"value":3,"unit":"m"}.
+func Quantity(value float64, unit string) Value {
+	return toIface(&quantity{value: value, unit: unit})
+}
+
+// Quantity returns the number and unit from a value created by
+// box.Quantity. Returns false for any other value.
+func (v Value) Quantity() (value float64, unit string, ok bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return 0, "", false
+	}
+	q, ok := v.assertNonPrimAny().(*quantity)
+	if !ok {
+		return 0, "", false
+	}
+	return q.value, q.unit, true
+}
+
+var unitTable struct {
+	sync.RWMutex
+	m map[[2]string]float64
+}
+
+// RegisterUnit records the conversion from one unit to another, such that
+// a quantity in the from unit multiplied by factor is in the to unit.
+// The inverse conversion is also recorded.
+func RegisterUnit(from, to string, factor float64) {
+	unitTable.Lock()
+	if unitTable.m == nil {
+		unitTable.m = make(map[[2]string]float64)
+	}
+	unitTable.m[[2]string{from, to}] = factor
+	unitTable.m[[2]string{to, from}] = 1 / factor
+	unitTable.Unlock()
+}
+
+// ConvertUnit converts a value created by box.Quantity to the to unit
+// using the conversions recorded by RegisterUnit. Returns an error when the
+// value is not a quantity or there is no conversion between the units.
+func (v Value) ConvertUnit(to string) (Value, error) {
+	x, from, ok := v.Quantity()
+	if !ok {
+		return Value{}, fmt.Errorf("box: %v is not a quantity", v.Type())
+	}
+	if from == to {
+		return v, nil
+	}
+	unitTable.RLock()
+	factor, ok := unitTable.m[[2]string{from, to}]
+	unitTable.RUnlock()
+	if !ok {
+		return Value{}, fmt.Errorf("box: no conversion from %q to %q", from,
+			to)
+	}
+	return Quantity(x*factor, to), nil
+}
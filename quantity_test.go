@@ -0,0 +1,43 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQuantity(t *testing.T) {
+	v := Quantity(3, "m")
+	assert(v.String() == "3 m")
+	assert(v.Float64() == 3)
+	x, unit, ok := v.Quantity()
+	assert(ok && x == 3 && unit == "m")
+	assert(Quantity(1.5, "").String() == "1.5")
+	_, _, ok = Float64(3).Quantity()
+	assert(!ok)
+	_, _, ok = String("3 m").Quantity()
+	assert(!ok)
+	data, err := json.Marshal(v)
+	assert(err == nil && string(data) == `{"value":3,"unit":"m"}`)
+	data, err = json.Marshal(Quantity(1.5, ""))
+	assert(err == nil && string(data) == `{"value":1.5,"unit":""}`)
+
+	RegisterUnit("ft", "in", 12)
+	c, err := Quantity(2, "ft").ConvertUnit("in")
+	assert(err == nil)
+	x, unit, ok = c.Quantity()
+	assert(ok && x == 24 && unit == "in")
+	assert(c.String() == "24 in")
+	c, err = Quantity(6, "in").ConvertUnit("ft")
+	assert(err == nil && c.String() == "0.5 ft")
+	c, err = v.ConvertUnit("m")
+	assert(err == nil && c.String() == "3 m")
+
+	_, err = v.ConvertUnit("ft")
+	assert(err != nil)
+	_, err = Float64(3).ConvertUnit("ft")
+	assert(err != nil)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"image/color"
+	"reflect"
+	"time"
+)
+
+var (
+	boolRType    = reflect.TypeOf(false)
+	int64RType   = reflect.TypeOf(int64(0))
+	uint64RType  = reflect.TypeOf(uint64(0))
+	float64RType = reflect.TypeOf(float64(0))
+	stringRType  = reflect.TypeOf("")
+	bytesRType   = reflect.TypeOf([]byte(nil))
+	timeRType    = reflect.TypeOf(time.Time{})
+	durRType     = reflect.TypeOf(time.Duration(0))
+	runeRType    = reflect.TypeOf(rune(0))
+	colorRType   = reflect.TypeOf(color.RGBA{})
+	enumSetRType = reflect.TypeOf((*enumSet)(nil))
+)
+
+// Type returns the reflect.Type of the boxed value.
+// Primitives report the type that Any() would return, such as int64 for
+// box.Int, and strings and byte slices report string and []byte.
+// Values boxed through the interface path report their dynamic type.
+// Returns nil for a Nil value.
+func (v Value) Type() reflect.Type {
+	switch v.ptr {
+	case nil:
+		return nil
+	case boolType:
+		return boolRType
+	case int64Type:
+		return int64RType
+	case uint64Type, custBitsType, idType:
+		return uint64RType
+	case float64Type, percentType:
+		return float64RType
+	case timeType:
+		return timeRType
+	case durationType:
+		return durRType
+	case runeType:
+		return runeRType
+	case colorType:
+		return colorRType
+	case enumSetType:
+		return enumSetRType
+	case semverType:
+		return stringRType
+	}
+	if isExact(v.ptr) {
+		return reflect.TypeOf(v.primToAny())
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return stringRType
+	case ptrBytes:
+		return bytesRType
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *correlated:
+		return vf.v.Type()
+	case *taggedString:
+		return stringRType
+	case *lazyBytes, *taggedBytes:
+		return bytesRType
+	default:
+		return reflect.TypeOf(vf)
+	}
+}
+
+// TypeName returns the name of the Go type of the boxed value, such as
+// "int64", "string", "[]uint8", or "*mypkg.Thing". This is the same as
+// Type().String(), except that a Nil value returns "nil".
+// Does not allocate.
+func (v Value) TypeName() string {
+	t := v.Type()
+	if t == nil {
+		return "nil"
+	}
+	return t.String()
+}
+
+// ReflectType returns the reflect.Type of the boxed value.
+// This is the same as Type().
+func (v Value) ReflectType() reflect.Type {
+	return v.Type()
+}
+
+// ReflectValue returns a reflect.Value holding the boxed value, with the
+// same type as reported by Type(). Returns the zero reflect.Value for a Nil
+// value. Primitives, strings, and byte slices are copied into a new
+// interface and allocate, while values boxed with box.Any reuse their
+// existing interface and do not.
+func (v Value) ReflectValue() reflect.Value {
+	if v.isPrim() {
+		if v.ptr == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(v.primToAny())
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *correlated:
+		return vf.v.ReflectValue()
+	case *taggedString:
+		return reflect.ValueOf(vf.str)
+	case *lazyBytes:
+		return reflect.ValueOf(vf.bytes())
+	case *taggedBytes:
+		return reflect.ValueOf(vf.bytes)
+	default:
+		return reflect.ValueOf(vf)
+	}
+}
+
+// Reflect returns a reflect.Value holding the boxed value. This is the same
+// as ReflectValue. Primitives reflect as their concrete kinds, such as
+// reflect.Int64 for box.Int64 and reflect.Bool for box.Bool, and strings
+// and byte slices as reflect.String and reflect.Slice. A Nil value returns
+// the zero reflect.Value, for which IsValid() is false.
+func (v Value) Reflect() reflect.Value {
+	return v.ReflectValue()
+}
+
+// Interface returns the boxed value as an interface. This is the same as
+// Any(), for interop with code that expects an Interface method, as
+// reflect.Value has.
+func (v Value) Interface() any {
+	return v.Any()
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestType(t *testing.T) {
+	assert(Nil().Type() == nil)
+	assert(Bool(true).Type() == reflect.TypeOf(false))
+	assert(Int8(1).Type() == reflect.TypeOf(int64(0)))
+	assert(Uint16(1).Type() == reflect.TypeOf(uint64(0)))
+	assert(CustomBits(1).Type() == reflect.TypeOf(uint64(0)))
+	assert(Float32(1).Type() == reflect.TypeOf(float64(0)))
+	assert(String("hello").Type() == reflect.TypeOf(""))
+	assert(StringWithTag("hello", 1).Type() == reflect.TypeOf(""))
+	assert(Bytes([]byte("hello")).Type() == reflect.TypeOf([]byte(nil)))
+	assert(Any(Jello{1, 2}).Type() == reflect.TypeOf(Jello{}))
+	assert(Any(&Jello{1, 2}).Type() == reflect.TypeOf(&Jello{}))
+	forceIfaceStrs = true
+	assert(String("hello").Type() == reflect.TypeOf(""))
+	assert(StringWithTag("hello", 1).Type() == reflect.TypeOf(""))
+	assert(Bytes([]byte("hello")).Type() == reflect.TypeOf([]byte(nil)))
+	forceIfaceStrs = false
+	forceIfacePtrs = true
+	assert(Any(Jello{1, 2}).Type() == reflect.TypeOf(Jello{}))
+	assert(Any(Pudding{1, 2}).Type() == reflect.TypeOf(Pudding{}))
+	forceIfacePtrs = false
+}
+
+func TestTypeName(t *testing.T) {
+	assert(Nil().TypeName() == "nil")
+	assert(Bool(true).TypeName() == "bool")
+	assert(Int(1).TypeName() == "int64")
+	assert(Uint(1).TypeName() == "uint64")
+	assert(Float64(1).TypeName() == "float64")
+	assert(CustomBits(1).TypeName() == "uint64")
+	assert(Duration(1).TypeName() == "time.Duration")
+	assert(String("hello").TypeName() == "string")
+	assert(Bytes([]byte("hello")).TypeName() == "[]uint8")
+	assert(LazyBytes(nil, 0, 0).TypeName() == "[]uint8")
+	assert(Any(Jello{1, 2}).TypeName() == "box.Jello")
+	assert(Any(&Jello{1, 2}).TypeName() == "*box.Jello")
+	assert(Any([]int{1}).TypeName() == "[]int")
+	forceIfaceStrs = true
+	assert(String("hello").TypeName() == "string")
+	assert(StringWithTag("hello", 1).TypeName() == "string")
+	assert(Bytes([]byte("hello")).TypeName() == "[]uint8")
+	forceIfaceStrs = false
+	forceIfacePtrs = true
+	assert(Any(Jello{1, 2}).TypeName() == "box.Jello")
+	assert(Any(&Jello{1, 2}).TypeName() == "*box.Jello")
+	forceIfacePtrs = false
+
+	vals := []Value{Nil(), Bool(true), Int(1), Float64(1), String("a")}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, v := range vals {
+			_ = v.TypeName()
+		}
+	})
+	assert(allocs == 0)
+}
+
+func TestReflectValue(t *testing.T) {
+	assert(!Nil().ReflectValue().IsValid())
+	assert(Nil().ReflectType() == nil)
+	vals := []Value{
+		Bool(true), Int(-1), Uint(1), Float64(1.5), CustomBits(7),
+		Time(time.Unix(1, 0).UTC()), Percent(0.5), Duration(time.Second),
+		ID(1), Rune('a'), RGBA(1, 2, 3, 4), String("hello"),
+		StringWithTag("hello", 1), Complex128(1i), Any(Jello{1, 2}),
+		Any(&Jello{1, 2}), Any(map[string]int{"a": 1}),
+	}
+	check := func(v Value) {
+		rv := v.ReflectValue()
+		assert(rv.IsValid())
+		assert(rv.Type() == v.ReflectType())
+		assert(reflect.DeepEqual(rv.Interface(), v.Any()))
+	}
+	for _, v := range vals {
+		check(v)
+	}
+	forceIfacePtrs = true
+	check(Any(Jello{1, 2}))
+	check(Any(&Jello{1, 2}))
+	check(Any(map[string]int{"a": 1}))
+	forceIfacePtrs = false
+
+	rv := Bytes([]byte("hello")).ReflectValue()
+	assert(rv.Kind() == reflect.Slice && string(rv.Bytes()) == "hello")
+	rv = Int(5).ReflectValue()
+	assert(rv.Kind() == reflect.Int64 && rv.Int() == 5)
+	forceIfaceStrs = true
+	rv = StringWithTag("hello", 1).ReflectValue()
+	assert(rv.Kind() == reflect.String && rv.String() == "hello")
+	rv = Bytes([]byte("hello")).ReflectValue()
+	assert(rv.Kind() == reflect.Slice && string(rv.Bytes()) == "hello")
+	forceIfaceStrs = false
+	rv = LazyBytes(strings.NewReader("hello"), 0, 5).ReflectValue()
+	assert(rv.Kind() == reflect.Slice && string(rv.Bytes()) == "hello")
+}
+
+func TestReflect(t *testing.T) {
+	assert(!Nil().Reflect().IsValid() && Nil().Interface() == nil)
+	for _, c := range []struct {
+		v    Value
+		kind reflect.Kind
+	}{
+		{Bool(true), reflect.Bool}, {Int(-1), reflect.Int64},
+		{Uint(1), reflect.Uint64}, {Float64(1.5), reflect.Float64},
+		{Float32(1.5), reflect.Float64}, {String("hi"), reflect.String},
+		{Bytes([]byte("hi")), reflect.Slice},
+		{Any(Jello{1, 2}), reflect.Struct}, {Any(&Jello{}), reflect.Pointer},
+	} {
+		rv := c.v.Reflect()
+		assert(rv.Kind() == c.kind && rv.Type() == c.v.Type())
+		assert(reflect.DeepEqual(rv.Interface(), c.v.Interface()))
+		assert(reflect.DeepEqual(c.v.Interface(), c.v.Any()))
+	}
+	assert(Int(5).Reflect().Int() == 5)
+	assert(String("hi").Reflect().String() == "hi")
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var errReprSyntax = errors.New("box: invalid repr syntax")
+
+// Repr returns text for the value that tells its kind apart, such as for a
+// debugging prompt, and that ParseRepr reads back. Unlike String, which
+// writes Int(42), Uint(42), and String("42") all as "42", each kind has its
+// own form:
+//
+//	Nil                 nil
+//	Bool                true or false
+//	Int                 42 or -42
+//	Uint                42u
+//	Float               42.0, 1e+21, NaN, +Inf, or -Inf
+//	CustomBits          cb:42
+//	String              "42", quoted as in Go
+//	Bytes               0x2a in hex, or 0x when empty
+//	Time                time:2006-01-02T15:04:05.999999999Z
+//	Percent             pct:0.5
+//	Duration            dur:1m30s
+//	ID                  id:000000000000002a
+//	Rune                'a', or rune:-1 when not a valid rune
+//	RGBA                #ff8000ff
+//	EnumSet             {1, 3}
+//	SemVer              v1.2.3
+//	Complex128          (1+2i)
+//
+// A string or byte slice with a tag is followed by # and the tag, such as
+// "hi"#7. Any other value boxed with box.Any is written as by GoString, and
+// cannot be read back.
+func (v Value) Repr() string {
+	v = v.uncorrelated()
+	switch v.Kind() {
+	case KindNil:
+		return "nil"
+	case KindBool:
+		return strconv.FormatBool(v.Bool())
+	case KindInt:
+		return strconv.FormatInt(v.Int64(), 10)
+	case KindUint:
+		return strconv.FormatUint(v.Uint64(), 10) + "u"
+	case KindFloat:
+		return reprFloat(v.Float64())
+	case KindCustom:
+		return "cb:" + strconv.FormatUint(v.Uint64(), 10)
+	case KindString:
+		return strconv.Quote(v.String()) + reprTag(v.Tag())
+	case KindBytes:
+		return "0x" + hex.EncodeToString(v.Bytes()) + reprTag(v.Tag())
+	case KindTime:
+		return "time:" + v.Time().Format(time.RFC3339Nano)
+	case KindPercent:
+		return "pct:" + strconv.FormatFloat(v.Float64(), 'g', -1, 64)
+	case KindDuration:
+		return "dur:" + v.Duration().String()
+	case KindID:
+		return "id:" + string(appendID(nil, v.ID()))
+	case KindRune:
+		if r := v.Rune(); utf8.ValidRune(r) {
+			return strconv.QuoteRune(r)
+		}
+		return "rune:" + strconv.FormatInt(v.Int64(), 10)
+	case KindColor, KindEnumSet:
+		return v.String()
+	case KindSemVer:
+		return "v" + v.String()
+	}
+	if v.IsComplex() {
+		return strconv.FormatComplex(v.Complex128(), 'g', -1, 128)
+	}
+	return v.GoString()
+}
+
+func reprFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+	return s + ".0"
+}
+
+func reprTag(tag uint16) string {
+	if tag == 0 {
+		return ""
+	}
+	return "#" + strconv.FormatUint(uint64(tag), 10)
+}
+
+// ParseRepr parses text written by Value.Repr and returns the value. Values
+// that Repr writes as by GoString cannot be parsed and return an error.
+func ParseRepr(s string) (Value, error) {
+	switch s {
+	case "":
+		return Value{}, errReprSyntax
+	case "nil":
+		return Nil(), nil
+	case "true":
+		return Bool(true), nil
+	case "false":
+		return Bool(false), nil
+	case "NaN", "+Inf", "-Inf":
+		f, _ := strconv.ParseFloat(s, 64)
+		return Float64(f), nil
+	}
+	switch {
+	case s[0] == '"':
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return Value{}, errReprSyntax
+		}
+		tag, ok := parseReprTag(s[len(q):])
+		if !ok {
+			return Value{}, errReprSyntax
+		}
+		str, _ := strconv.Unquote(q)
+		if tag != 0 {
+			return StringWithTag(str, tag), nil
+		}
+		return String(str), nil
+	case strings.HasPrefix(s, "0x"):
+		s = s[2:]
+		end := strings.IndexByte(s, '#')
+		if end == -1 {
+			end = len(s)
+		}
+		tag, ok := parseReprTag(s[end:])
+		b, err := hex.DecodeString(s[:end])
+		if !ok || err != nil {
+			return Value{}, errReprSyntax
+		}
+		if tag != 0 {
+			return BytesWithTag(b, tag), nil
+		}
+		return Bytes(b), nil
+	case s[0] == '\'':
+		r, _, tail, err := strconv.UnquoteChar(s[1:], '\'')
+		if err != nil || tail != "'" {
+			return Value{}, errReprSyntax
+		}
+		return Rune(r), nil
+	case s[0] == '#':
+		return ParseColor(s)
+	case s[0] == '{':
+		return parseReprEnumSet(s)
+	case s[0] == '(':
+		c, err := strconv.ParseComplex(s, 128)
+		if err != nil {
+			return Value{}, errReprSyntax
+		}
+		return Complex128(c), nil
+	case s[0] == 'v':
+		return ParseSemVer(s)
+	}
+	if i := strings.IndexByte(s, ':'); i != -1 {
+		return parseReprPrefixed(s[:i], s[i+1:])
+	}
+	if s[len(s)-1] == 'u' {
+		x, err := strconv.ParseUint(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return Value{}, errReprSyntax
+		}
+		return Uint64(x), nil
+	}
+	if strings.ContainsAny(s, ".eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return Value{}, errReprSyntax
+		}
+		return Float64(f), nil
+	}
+	x, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return Value{}, errReprSyntax
+	}
+	return Int64(x), nil
+}
+
+func parseReprTag(s string) (uint16, bool) {
+	if s == "" {
+		return 0, true
+	}
+	if s[0] != '#' {
+		return 0, false
+	}
+	tag, err := strconv.ParseUint(s[1:], 10, 16)
+	return uint16(tag), err == nil
+}
+
+func parseReprPrefixed(prefix, s string) (Value, error) {
+	switch prefix {
+	case "cb":
+		if x, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return CustomBits(x), nil
+		}
+	case "time":
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return Time(t), nil
+		}
+	case "pct":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return Percent(f), nil
+		}
+	case "dur":
+		if d, err := time.ParseDuration(s); err == nil {
+			return Duration(d), nil
+		}
+	case "id":
+		if x, err := strconv.ParseUint(s, 16, 64); err == nil {
+			return ID(x), nil
+		}
+	case "rune":
+		if x, err := strconv.ParseInt(s, 10, 32); err == nil {
+			return Rune(rune(x)), nil
+		}
+	}
+	return Value{}, errReprSyntax
+}
+
+func parseReprEnumSet(s string) (Value, error) {
+	if len(s) < 2 || s[len(s)-1] != '}' {
+		return Value{}, errReprSyntax
+	}
+	s = s[1 : len(s)-1]
+	var codes []uint64
+	if s != "" {
+		for _, part := range strings.Split(s, ", ") {
+			code, err := strconv.ParseUint(part, 10, 64)
+			if err != nil {
+				return Value{}, errReprSyntax
+			}
+			codes = append(codes, code)
+		}
+	}
+	return EnumSet(codes...), nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRepr(t *testing.T) {
+	for _, c := range []struct {
+		v    Value
+		repr string
+	}{
+		{Nil(), "nil"},
+		{Bool(true), "true"},
+		{Bool(false), "false"},
+		{Int(42), "42"},
+		{Int(-42), "-42"},
+		{Int64(math.MinInt64), "-9223372036854775808"},
+		{Uint(42), "42u"},
+		{Uint64(math.MaxUint64), "18446744073709551615u"},
+		{Float64(42), "42.0"},
+		{Float64(-0.5), "-0.5"},
+		{Float64(1e21), "1e+21"},
+		{Float64(math.Copysign(0, -1)), "-0.0"},
+		{Float64(math.Inf(1)), "+Inf"},
+		{Float64(math.Inf(-1)), "-Inf"},
+		{CustomBits(42), "cb:42"},
+		{String("42"), `"42"`},
+		{String(""), `""`},
+		{String("a \"b\"\n"), `"a \"b\"\n"`},
+		{StringWithTag("hi", 7), `"hi"#7`},
+		{String("#7"), `"#7"`},
+		{Bytes([]byte{0x2a, 0xff}), "0x2aff"},
+		{Bytes(nil), "0x"},
+		{BytesWithTag([]byte{1}, 300), "0x01#300"},
+		{Time(time.Unix(1, 5).UTC()), "time:1970-01-01T00:00:01.000000005Z"},
+		{Percent(0.5), "pct:0.5"},
+		{Duration(90 * time.Second), "dur:1m30s"},
+		{Duration(math.MinInt64), "dur:-2562047h47m16.854775808s"},
+		{ID(42), "id:000000000000002a"},
+		{Rune('a'), "'a'"},
+		{Rune('\''), `'\''`},
+		{Rune('世'), "'世'"},
+		{Rune(-1), "rune:-1"},
+		{RGBA(0xff, 0x80, 0, 0xff), "#ff8000ff"},
+		{EnumSet(3, 1), "{1, 3}"},
+		{EnumSet(), "{}"},
+		{EnumSet(1, 100), "{1, 100}"},
+		{SemVer(1, 2, 3), "v1.2.3"},
+		{Complex128(1 + 2i), "(1+2i)"},
+		{Int(42).WithCorrelation(9), "42"},
+		{AnyExact(int8(-4)), "-4"},
+		{AnyExact(float32(1.5)), "1.5"},
+	} {
+		if got := c.v.Repr(); got != c.repr {
+			t.Fatalf("%#v: expected %s, got %s", c.v, c.repr, got)
+		}
+		v, err := ParseRepr(c.repr)
+		if err != nil {
+			t.Fatalf("%s: %v", c.repr, err)
+		}
+		if v.Repr() != c.repr || v.Kind() != c.v.Kind() ||
+			v.Tag() != c.v.Tag() {
+			t.Fatalf("%s: round trip gave %#v", c.repr, v)
+		}
+		// AnyExact scalars come back as the wider value, and correlation
+		// ids are not kept
+		want := c.v.unwrap()
+		if !v.EqualIdentity(want) && !v.DeepEqual(want) {
+			t.Fatalf("%s: round trip gave %#v", c.repr, v)
+		}
+	}
+	v, err := ParseRepr("NaN")
+	assert(err == nil && math.IsNaN(v.Float64()) && v.Repr() == "NaN")
+	v, err = ParseRepr("1.5e-300")
+	assert(err == nil && v.Float64() == 1.5e-300)
+
+	// non-UTC times keep their offset
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600))
+	v, err = ParseRepr(Time(tm).Repr())
+	assert(err == nil && v.Time().Equal(tm))
+	assert(Time(tm).Repr() == "time:2020-01-02T03:04:05+01:00")
+
+	// other values are written as by GoString and cannot be parsed
+	v = Any(Jello{1, 2})
+	assert(v.Repr() == v.GoString())
+	_, err = ParseRepr(v.Repr())
+	assert(err != nil)
+
+	for _, s := range []string{
+		"", "nul", "True", "42x", "1_000", "0x1", "0xzz", `"abc`, `"a"#`,
+		`"a"x`, `"a"#70000`, "'ab'", "''", "#12345", "{1,2}", "{1, a}",
+		"(1+)", "v1.2", "cb:-1", "time:yesterday", "dur:5", "pct:x",
+		"id:xyz", "rune:5000000000", "foo:1", "-u", "1.5u", " 42",
+	} {
+		if _, err := ParseRepr(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strconv"
+)
+
+// RoundMode is how a float is rounded when converted to an integer.
+type RoundMode uint8
+
+const (
+	RoundTrunc    RoundMode = iota // toward zero, like Int64
+	RoundHalfEven                  // to nearest, ties to even
+	RoundHalfAway                  // to nearest, ties away from zero
+	RoundFloor                     // toward negative infinity
+	RoundCeil                      // toward positive infinity
+)
+
+func (mode RoundMode) round(f float64) float64 {
+	switch mode {
+	case RoundTrunc:
+		return math.Trunc(f)
+	case RoundHalfEven:
+		return math.RoundToEven(f)
+	case RoundHalfAway:
+		return math.Round(f)
+	case RoundFloor:
+		return math.Floor(f)
+	default:
+		return math.Ceil(f)
+	}
+}
+
+// Int64Rounded returns the value as an int64, rounding floats using mode.
+// Floats, and strings that parse as floats, are rounded and then clamped to
+// the range of an int64, such that +Inf returns math.MaxInt64 and -Inf
+// returns math.MinInt64. NaN returns 0. Integers are returned unchanged,
+// clamped to the range of an int64, and other values convert like Int64.
+// Panics if mode is not a valid RoundMode.
+func (v Value) Int64Rounded(mode RoundMode) int64 {
+	return v.rounded(mode).satInt(math.MinInt64, math.MaxInt64)
+}
+
+// Uint64Rounded is like Int64Rounded for a uint64. Values below zero after
+// rounding return 0.
+func (v Value) Uint64Rounded(mode RoundMode) uint64 {
+	return v.rounded(mode).satUint(math.MaxUint64)
+}
+
+func (v Value) rounded(mode RoundMode) Value {
+	if mode > RoundCeil {
+		panic("box: invalid rounding mode " + strconv.Itoa(int(mode)))
+	}
+	if n, ok := v.Number(); ok && n.kind == 'f' {
+		return Float64(mode.round(math.Float64frombits(n.bits)))
+	}
+	return v
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRounded(t *testing.T) {
+	modes := []RoundMode{RoundTrunc, RoundHalfEven, RoundHalfAway, RoundFloor,
+		RoundCeil}
+	tests := []struct {
+		f    float64
+		want [5]int64 // in the order of modes
+	}{
+		{2.7, [5]int64{2, 3, 3, 2, 3}},
+		{-2.7, [5]int64{-2, -3, -3, -3, -2}},
+		{2.5, [5]int64{2, 2, 3, 2, 3}},
+		{3.5, [5]int64{3, 4, 4, 3, 4}},
+		{-2.5, [5]int64{-2, -2, -3, -3, -2}},
+		{-3.5, [5]int64{-3, -4, -4, -4, -3}},
+		{0.5, [5]int64{0, 0, 1, 0, 1}},
+		{-0.5, [5]int64{0, 0, -1, -1, 0}},
+		{2.2, [5]int64{2, 2, 2, 2, 3}},
+		{2, [5]int64{2, 2, 2, 2, 2}},
+		{math.NaN(), [5]int64{0, 0, 0, 0, 0}},
+		{math.Inf(1), [5]int64{math.MaxInt64, math.MaxInt64, math.MaxInt64,
+			math.MaxInt64, math.MaxInt64}},
+		{math.Inf(-1), [5]int64{math.MinInt64, math.MinInt64, math.MinInt64,
+			math.MinInt64, math.MinInt64}},
+		// 2^63 and the float just below it
+		{1 << 63, [5]int64{math.MaxInt64, math.MaxInt64, math.MaxInt64,
+			math.MaxInt64, math.MaxInt64}},
+		{math.Nextafter(1<<63, 0), [5]int64{1<<63 - 1024, 1<<63 - 1024,
+			1<<63 - 1024, 1<<63 - 1024, 1<<63 - 1024}},
+		{-1 << 63, [5]int64{math.MinInt64, math.MinInt64, math.MinInt64,
+			math.MinInt64, math.MinInt64}},
+		{math.Nextafter(-1<<63, -math.MaxFloat64), [5]int64{math.MinInt64,
+			math.MinInt64, math.MinInt64, math.MinInt64, math.MinInt64}},
+		{1e300, [5]int64{math.MaxInt64, math.MaxInt64, math.MaxInt64,
+			math.MaxInt64, math.MaxInt64}},
+	}
+	for i, tt := range tests {
+		for j, mode := range modes {
+			got := Float64(tt.f).Int64Rounded(mode)
+			if got != tt.want[j] {
+				t.Fatalf("%d: %v mode %d: expected %d, got %d", i, tt.f, mode,
+					tt.want[j], got)
+			}
+		}
+	}
+
+	assert(Float64(2.7).Int64() == 2)
+	assert(String("2.5").Int64Rounded(RoundHalfAway) == 3)
+	assert(String("-2.5").Int64Rounded(RoundCeil) == -2)
+	assert(Percent(0.5).Int64Rounded(RoundHalfEven) == 0)
+	assert(Int(-7).Int64Rounded(RoundCeil) == -7)
+	assert(Uint(math.MaxUint64).Int64Rounded(RoundFloor) == math.MaxInt64)
+	assert(Nil().Int64Rounded(RoundCeil) == 0)
+	assert(Bool(true).Int64Rounded(RoundFloor) == 1)
+
+	assert(Float64(2.5).Uint64Rounded(RoundHalfEven) == 2)
+	assert(Float64(2.5).Uint64Rounded(RoundHalfAway) == 3)
+	assert(Float64(-0.5).Uint64Rounded(RoundFloor) == 0)
+	assert(Float64(-0.5).Uint64Rounded(RoundCeil) == 0)
+	assert(Float64(0.1).Uint64Rounded(RoundCeil) == 1)
+	assert(Float64(math.NaN()).Uint64Rounded(RoundCeil) == 0)
+	assert(Float64(math.Inf(1)).Uint64Rounded(RoundFloor) == math.MaxUint64)
+	assert(Float64(1<<64).Uint64Rounded(RoundFloor) == math.MaxUint64)
+	assert(Float64(math.Nextafter(1<<64, 0)).Uint64Rounded(RoundCeil) ==
+		1<<64-2048)
+	assert(Uint(math.MaxUint64).Uint64Rounded(RoundTrunc) == math.MaxUint64)
+	assert(Int(-1).Uint64Rounded(RoundTrunc) == 0)
+
+	var panicked bool
+	func() {
+		defer func() { panicked = recover() != nil }()
+		Int(1).Int64Rounded(RoundMode(99))
+	}()
+	assert(panicked)
+}
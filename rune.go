@@ -0,0 +1,38 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "unicode/utf8"
+
+// Rune boxes a rune
+// The rune is stored inline and does not allocate. Unlike box.Int32,
+// String() returns the UTF-8 encoding of the rune, such that
+// Rune('a').String() == "a", while Int64() returns its code point.
+//
+// A rune is an alias for int32, so box.Any cannot tell a rune apart from
+// an int32 and boxes it as an Int64. Use box.Rune explicitly.
+func Rune(r rune) Value {
+	return Value{uint64(int64(r)), runeType}
+}
+
+// Rune returns the value as a rune.
+// A string or byte slice holding exactly one UTF-8 encoded rune returns
+// that rune. Other values are converted using Int32().
+func (v Value) Rune() rune {
+	v = v.unwrap()
+	if v.ptr == runeType {
+		return rune(v.ext)
+	}
+	if v.IsString() || v.IsBytes() {
+		s := v.String()
+		if r, n := utf8.DecodeRuneInString(s); n > 0 && n == len(s) {
+			return r
+		}
+	}
+	return v.Int32()
+}
+
+// IsRune returns true if the boxed value was created using box.Rune.
+func (v Value) IsRune() bool { return v.unwrap().ptr == runeType }
@@ -0,0 +1,44 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRune(t *testing.T) {
+	v := Rune('a')
+	assert(v.IsRune() && !v.IsInt())
+	assert(v.Rune() == 'a')
+	assert(v.String() == "a")
+	assert(string(v.AppendString(nil)) == "a")
+	assert(v.Int64() == 97 && v.Int32() == 97)
+	assert(v.Any().(rune) == 'a')
+	assert(v.Kind() == KindRune)
+	assert(v.TypeName() == "int32")
+	assert(Rune('世').String() == "世")
+	assert(Rune(-1).Int64() == -1)
+	assert(Rune(-1).String() == "�")
+	assert(Int32('a').String() == "97")
+	assert(!Int32('a').IsRune())
+	assert(!Any('a').IsRune() && Any('a').IsInt())
+	assert(Int32('a').Rune() == 'a')
+	assert(String("世").Rune() == '世')
+	assert(Bytes([]byte("b")).Rune() == 'b')
+	assert(String("ab").Rune() == 0)
+	assert(Rune(0).IsZero())
+	assert(fmt.Sprintf("%v %c %d %#v", v, v, v, v) == "a a 97 box.Rune('a')")
+
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var v2 Value
+	assert(v2.UnmarshalBinary(data) == nil)
+	assert(v2.IsRune() && v2.Rune() == 'a')
+	data, err = v.MarshalJSON()
+	assert(err == nil && string(data) == `"a"`)
+	dv, err := v.Value()
+	assert(err == nil && dv.(string) == "a")
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "math"
+
+// Int8Sat returns the value as an int8, clamped to the range of an int8.
+// Unlike Int8, values out of range do not wrap. NaN returns 0.
+func (v Value) Int8Sat() int8 {
+	return int8(v.satInt(math.MinInt8, math.MaxInt8))
+}
+
+// Int16Sat returns the value as an int16, clamped to the range of an int16.
+func (v Value) Int16Sat() int16 {
+	return int16(v.satInt(math.MinInt16, math.MaxInt16))
+}
+
+// Int32Sat returns the value as an int32, clamped to the range of an int32.
+func (v Value) Int32Sat() int32 {
+	return int32(v.satInt(math.MinInt32, math.MaxInt32))
+}
+
+// IntSat returns the value as an int, clamped to the range of an int.
+func (v Value) IntSat() int { return int(v.satInt(math.MinInt, math.MaxInt)) }
+
+// Uint8Sat returns the value as a uint8, clamped to the range of a uint8.
+// Negative values return 0, and so does NaN.
+func (v Value) Uint8Sat() uint8 { return uint8(v.satUint(math.MaxUint8)) }
+
+// Uint16Sat returns the value as a uint16, clamped to the range of a
+// uint16.
+func (v Value) Uint16Sat() uint16 { return uint16(v.satUint(math.MaxUint16)) }
+
+// Uint32Sat returns the value as a uint32, clamped to the range of a
+// uint32.
+func (v Value) Uint32Sat() uint32 { return uint32(v.satUint(math.MaxUint32)) }
+
+// UintSat returns the value as a uint, clamped to the range of a uint.
+func (v Value) UintSat() uint { return uint(v.satUint(math.MaxUint)) }
+
+// satInt returns the value clamped to [lo, hi]. Numbers and strings that
+// parse as numbers are clamped exactly, and floats are truncated toward
+// zero. Any other value is converted using Int64 first.
+func (v Value) satInt(lo, hi int64) int64 {
+	n, ok := v.Number()
+	if !ok {
+		n = Number{uint64(v.Int64()), 'i'}
+	}
+	switch n.kind {
+	case 'u':
+		if n.bits > uint64(hi) {
+			return hi
+		}
+		return int64(n.bits)
+	case 'f':
+		f := math.Float64frombits(n.bits)
+		switch {
+		case math.IsNaN(f):
+			return 0
+		case f <= float64(lo):
+			return lo
+		case f >= float64(hi):
+			return hi
+		}
+		return int64(f)
+	}
+	x := int64(n.bits)
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+// satUint is like satInt for an unsigned range of [0, hi].
+func (v Value) satUint(hi uint64) uint64 {
+	n, ok := v.Number()
+	if !ok {
+		n = Number{uint64(v.Int64()), 'i'}
+	}
+	switch n.kind {
+	case 'i':
+		if int64(n.bits) < 0 {
+			return 0
+		}
+	case 'f':
+		f := math.Float64frombits(n.bits)
+		switch {
+		case math.IsNaN(f), f <= 0:
+			return 0
+		case f >= float64(hi):
+			return hi
+		}
+		return uint64(f)
+	}
+	if n.bits > hi {
+		return hi
+	}
+	return n.bits
+}
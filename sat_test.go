@@ -0,0 +1,66 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSaturate(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	assert(Int(500).Int8Sat() == math.MaxInt8)
+	assert(Int(500).Int8() != math.MaxInt8) // wraps
+	assert(Int(-500).Int8Sat() == math.MinInt8)
+	assert(Int(127).Int8Sat() == 127 && Int(128).Int8Sat() == 127)
+	assert(Int(-128).Int8Sat() == -128 && Int(-129).Int8Sat() == -128)
+	assert(Uint(math.MaxUint64).Int8Sat() == 127)
+	assert(Float64(126.9).Int8Sat() == 126)
+	assert(Float64(-128.5).Int8Sat() == -128)
+	assert(Float64(nan).Int8Sat() == 0)
+	assert(Float64(inf).Int8Sat() == 127 && Float64(-inf).Int8Sat() == -128)
+	assert(String("1000").Int8Sat() == 127)
+	assert(String("-2.5").Int8Sat() == -2)
+	assert(Nil().Int8Sat() == 0 && Bool(true).Int8Sat() == 1)
+
+	assert(Int(math.MaxInt16).Int16Sat() == math.MaxInt16)
+	assert(Int(math.MaxInt16+1).Int16Sat() == math.MaxInt16)
+	assert(Int(math.MinInt16-1).Int16Sat() == math.MinInt16)
+	assert(Float64(inf).Int16Sat() == math.MaxInt16)
+	assert(Float64(nan).Int16Sat() == 0)
+
+	assert(Int(math.MaxInt32+1).Int32Sat() == math.MaxInt32)
+	assert(Int(math.MinInt32-1).Int32Sat() == math.MinInt32)
+	assert(Float64(1e300).Int32Sat() == math.MaxInt32)
+	assert(Float64(-inf).Int32Sat() == math.MinInt32)
+
+	assert(Uint(math.MaxUint64).IntSat() == math.MaxInt)
+	assert(Float64(inf).IntSat() == math.MaxInt)
+	assert(Float64(-inf).IntSat() == math.MinInt)
+	assert(Float64(nan).IntSat() == 0)
+	assert(Float64(1<<63).IntSat() == math.MaxInt)
+	assert(Int(math.MinInt64).IntSat() == math.MinInt)
+
+	assert(Uint(500).Uint8Sat() == math.MaxUint8)
+	assert(Uint(500).Uint8() != math.MaxUint8) // wraps
+	assert(Int(-1).Uint8Sat() == 0 && Int(255).Uint8Sat() == 255)
+	assert(Int(256).Uint8Sat() == 255)
+	assert(Float64(-0.5).Uint8Sat() == 0 && Float64(255.9).Uint8Sat() == 255)
+	assert(Float64(nan).Uint8Sat() == 0)
+	assert(Float64(inf).Uint8Sat() == 255 && Float64(-inf).Uint8Sat() == 0)
+	assert(String("-7").Uint8Sat() == 0)
+
+	assert(Int(math.MaxUint16+1).Uint16Sat() == math.MaxUint16)
+	assert(Int(-1).Uint16Sat() == 0)
+	assert(Int(math.MaxUint32+1).Uint32Sat() == math.MaxUint32)
+	assert(Float64(inf).Uint32Sat() == math.MaxUint32)
+
+	assert(Uint(math.MaxUint64).UintSat() == math.MaxUint)
+	assert(Int(math.MinInt64).UintSat() == 0)
+	assert(Float64(inf).UintSat() == math.MaxUint)
+	assert(Float64(1<<64).UintSat() == math.MaxUint)
+	assert(Float64(nan).UintSat() == 0)
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+type fmtScanner struct{ v *Value }
+
+// Scanner returns a fmt.Scanner that reads into v, for use with fmt.Scan,
+// fmt.Sscanf and friends, such as fmt.Fscan(r, box.Scanner(&v)).
+// The Value type cannot implement fmt.Scanner itself because its Scan
+// method implements the database/sql Scanner interface.
+//
+// The %v verb reads a token and infers its type in the same way as Set.
+// The %d verb reads an Int64, %f, %g and %e read a Float64, and %t reads a
+// Bool. The %s verb reads a token as a String, and %q reads a double or
+// back quoted Go string. Parse errors are returned.
+func Scanner(v *Value) fmt.Scanner {
+	return fmtScanner{v}
+}
+
+func (s fmtScanner) Scan(state fmt.ScanState, verb rune) error {
+	if verb == 'q' {
+		str, err := scanQuoted(state)
+		if err != nil {
+			return err
+		}
+		*s.v = String(str)
+		return nil
+	}
+	tok, err := state.Token(true, func(r rune) bool {
+		return !unicode.IsSpace(r)
+	})
+	if err != nil {
+		return err
+	}
+	if len(tok) == 0 {
+		return errors.New("box: expected token")
+	}
+	switch verb {
+	case 'v':
+		return s.v.Set(string(tok))
+	case 's':
+		*s.v = String(string(tok))
+	case 'd':
+		x, err := strconv.ParseInt(string(tok), 10, 64)
+		if err != nil {
+			return err
+		}
+		*s.v = Int64(x)
+	case 'f', 'F', 'g', 'G', 'e', 'E':
+		x, err := strconv.ParseFloat(string(tok), 64)
+		if err != nil {
+			return err
+		}
+		*s.v = Float64(x)
+	case 't':
+		x, err := parseBool(string(tok))
+		if err != nil {
+			return err
+		}
+		*s.v = Bool(x)
+	default:
+		return fmt.Errorf("box: bad verb '%%%c' for scanning", verb)
+	}
+	return nil
+}
+
+// scanQuoted reads a double or back quoted Go string.
+func scanQuoted(state fmt.ScanState) (string, error) {
+	state.SkipSpace()
+	quote, _, err := state.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	if quote != '"' && quote != '`' {
+		return "", errors.New("box: expected quoted string")
+	}
+	buf := []rune{quote}
+	for {
+		r, _, err := state.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		buf = append(buf, r)
+		if r == '\\' && quote == '"' {
+			r, _, err = state.ReadRune()
+			if err != nil {
+				return "", err
+			}
+			buf = append(buf, r)
+			continue
+		}
+		if r == quote {
+			return strconv.Unquote(string(buf))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	var a, b, c, d, e Value
+	n, err := fmt.Sscan("10 -1.5 true hello 18446744073709551615",
+		Scanner(&a), Scanner(&b), Scanner(&c), Scanner(&d), Scanner(&e))
+	assert(err == nil && n == 5)
+	assert(a.IsInt() && a.Int() == 10)
+	assert(b.IsFloat() && b.Float64() == -1.5)
+	assert(c.IsBool() && c.Bool())
+	assert(d.IsString() && d.String() == "hello")
+	assert(e.IsUint() && e.Uint64() == 18446744073709551615)
+
+	_, err = fmt.Sscanf("10 10 10 1e3 false 10", "%v %s %d %f %t %g",
+		Scanner(&a), Scanner(&b), Scanner(&c), Scanner(&d), Scanner(&e),
+		Scanner(&e))
+	assert(err == nil)
+	assert(a.IsInt() && a.Int() == 10)
+	assert(b.IsString() && b.String() == "10")
+	assert(c.IsInt() && c.Int() == 10)
+	assert(d.IsFloat() && d.Float64() == 1000)
+	assert(e.IsFloat() && e.Float64() == 10)
+
+	_, err = fmt.Sscanf(`"hello \"world\"" `+"`raw \\n`"+` x`, "%q %q %s",
+		Scanner(&a), Scanner(&b), Scanner(&c))
+	assert(err == nil)
+	assert(a.IsString() && a.String() == `hello "world"`)
+	assert(b.String() == `raw \n`)
+	assert(c.String() == "x")
+
+	var r Value
+	rd := strings.NewReader("1 2 3")
+	for i := 1; i <= 3; i++ {
+		_, err = fmt.Fscan(rd, Scanner(&r))
+		assert(err == nil && r.Int() == i)
+	}
+
+	// malformed input
+	_, err = fmt.Sscanf("12abc", "%d", Scanner(&a))
+	assert(err != nil && strings.Contains(err.Error(), "invalid syntax"))
+	_, err = fmt.Sscanf("99999999999999999999", "%d", Scanner(&a))
+	assert(err != nil && strings.Contains(err.Error(), "out of range"))
+	_, err = fmt.Sscanf("abc", "%f", Scanner(&a))
+	assert(err != nil)
+	_, err = fmt.Sscanf("maybe", "%t", Scanner(&a))
+	assert(err != nil)
+	_, err = fmt.Sscanf("hello", "%q", Scanner(&a))
+	assert(err != nil)
+	_, err = fmt.Sscanf(`"hello`, "%q", Scanner(&a))
+	assert(err != nil)
+	_, err = fmt.Sscanf("10", "%x", Scanner(&a))
+	assert(err != nil)
+	_, err = fmt.Sscan("", Scanner(&a))
+	assert(err != nil)
+}
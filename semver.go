@@ -0,0 +1,92 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SemVer boxes a semantic version made of a major, minor, and patch number.
+// The version is stored inline and does not allocate. String() renders it
+// as "major.minor.patch", such as SemVer(1, 2, 3).String() == "1.2.3".
+func SemVer(major, minor, patch uint16) Value {
+	return Value{uint64(major)<<32 | uint64(minor)<<16 | uint64(patch),
+		semverType}
+}
+
+var errSemVerSyntax = errors.New("box: invalid semver syntax")
+
+// ParseSemVer parses a semantic version in the form "1.2.3", with an
+// optional leading "v". Each number must fit in a uint16. Pre-release and
+// build suffixes are not supported.
+func ParseSemVer(s string) (Value, error) {
+	s = strings.TrimPrefix(s, "v")
+	var parts [3]uint16
+	for i := range parts {
+		end := strings.IndexByte(s, '.')
+		if i == len(parts)-1 {
+			end = len(s)
+		} else if end == -1 {
+			return Value{}, errSemVerSyntax
+		}
+		x, err := strconv.ParseUint(s[:end], 10, 16)
+		if err != nil {
+			return Value{}, errSemVerSyntax
+		}
+		parts[i] = uint16(x)
+		if i < len(parts)-1 {
+			s = s[end+1:]
+		}
+	}
+	return SemVer(parts[0], parts[1], parts[2]), nil
+}
+
+// SemVer returns the major, minor, and patch numbers from a value created
+// by box.SemVer or box.ParseSemVer. A string or byte slice holding a
+// version is also parsed. Returns all zeros for any other value.
+func (v Value) SemVer() (major, minor, patch uint16) {
+	v = v.unwrap()
+	x := v.ext
+	if v.ptr != semverType {
+		x = 0
+		if v.IsString() || v.IsBytes() {
+			if sv, err := ParseSemVer(v.String()); err == nil {
+				x = sv.ext
+			}
+		}
+	}
+	return uint16(x >> 32), uint16(x >> 16), uint16(x)
+}
+
+// IsSemVer returns true if the boxed value was created using box.SemVer or
+// box.ParseSemVer.
+func (v Value) IsSemVer() bool { return v.unwrap().ptr == semverType }
+
+// CompareSemVer compares two versions by their major, then minor, then
+// patch number, and returns -1, 0, or +1. Strings and byte slices are
+// parsed as by SemVer(), and any other value orders as "0.0.0".
+func (v Value) CompareSemVer(other Value) int {
+	a1, b1, c1 := v.SemVer()
+	a2, b2, c2 := other.SemVer()
+	x := uint64(a1)<<32 | uint64(b1)<<16 | uint64(c1)
+	y := uint64(a2)<<32 | uint64(b2)<<16 | uint64(c2)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func appendSemVer(dst []byte, x uint64) []byte {
+	dst = strconv.AppendUint(dst, (x>>32)&0xFFFF, 10)
+	dst = append(dst, '.')
+	dst = strconv.AppendUint(dst, (x>>16)&0xFFFF, 10)
+	dst = append(dst, '.')
+	return strconv.AppendUint(dst, x&0xFFFF, 10)
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestSemVer(t *testing.T) {
+	v := SemVer(1, 2, 3)
+	assert(v.IsSemVer() && !v.IsUint() && v.Kind() == KindSemVer)
+	major, minor, patch := v.SemVer()
+	assert(major == 1 && minor == 2 && patch == 3)
+	assert(v.String() == "1.2.3" && string(v.AppendString(nil)) == "1.2.3")
+	assert(v.Any().(string) == "1.2.3")
+	assert(v.GoString() == "box.SemVer(1, 2, 3)")
+	assert(fmt.Sprintf("%q", v) == `"1.2.3"`)
+	assert(SemVer(65535, 0, 65535).String() == "65535.0.65535")
+	assert(SemVer(0, 0, 0).IsZero())
+	assert(allocs(func() { v = SemVer(4, 5, 6) }) == 0)
+
+	for _, s := range []string{"1.2.3", "v1.2.3", "01.2.03"} {
+		v, err := ParseSemVer(s)
+		assert(err == nil && v.IsSemVer() && v.String() == "1.2.3")
+	}
+	for _, s := range []string{
+		"", "v", "1", "1.2", "1.2.", "1..3", "1.2.3.4", "1.2.x", "-1.2.3",
+		"1.+2.3", "1.2.65536", "1.2.3-beta", " 1.2.3",
+	} {
+		_, err := ParseSemVer(s)
+		assert(err != nil)
+	}
+
+	major, minor, patch = String("v7.8.9").SemVer()
+	assert(major == 7 && minor == 8 && patch == 9)
+	major, minor, patch = Int(1).SemVer()
+	assert(major == 0 && minor == 0 && patch == 0)
+	assert(AnyAs("2.0.1", KindSemVer).String() == "2.0.1")
+	assert(AnyAs("2.0.1", KindSemVer).IsSemVer())
+
+	vers := []Value{
+		SemVer(1, 10, 0), SemVer(2, 0, 0), SemVer(1, 2, 10),
+		SemVer(0, 9, 9), SemVer(1, 2, 3), SemVer(1, 9, 65535),
+	}
+	sort.Slice(vers, func(i, j int) bool {
+		return vers[i].CompareSemVer(vers[j]) < 0
+	})
+	var strs []string
+	for _, v := range vers {
+		strs = append(strs, v.String())
+	}
+	assert(fmt.Sprint(strs) ==
+		"[0.9.9 1.2.3 1.2.10 1.9.65535 1.10.0 2.0.0]")
+	assert(SemVer(1, 2, 3).CompareSemVer(String("1.2.3")) == 0)
+	assert(SemVer(1, 2, 3).CompareSemVer(SemVer(1, 2, 4)) == -1)
+	assert(SemVer(1, 3, 0).CompareSemVer(SemVer(1, 2, 4)) == 1)
+
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var v2 Value
+	assert(v2.UnmarshalBinary(data) == nil)
+	assert(v2.IsSemVer() && v2.String() == "4.5.6")
+	data, err = v.MarshalJSON()
+	assert(err == nil && string(data) == `"4.5.6"`)
+	dv, err := v.Value()
+	assert(err == nil && dv.(string) == "4.5.6")
+	assert(v.TypeName() == "string")
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Scan implements the database/sql Scanner interface.
+// The driver types nil, int64, float64, bool, []byte, string, and
+// time.Time are boxed as their matching kinds. A []byte is copied because
+// drivers may reuse the buffer for the next row. Any other type is boxed
+// using box.Any.
+func (v *Value) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*v = Nil()
+	case int64:
+		*v = Int64(src)
+	case float64:
+		*v = Float64(src)
+	case bool:
+		*v = Bool(src)
+	case []byte:
+		*v = Bytes(append([]byte{}, src...))
+	case string:
+		*v = String(src)
+	case time.Time:
+		*v = Time(src)
+	default:
+		*v = Any(src)
+	}
+	return nil
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Primitives, strings, byte slices, and times are returned as their driver
+// types. Runes are returned as single character strings, colors as hex
+// strings, and enum sets as their String() form. A Uint64 or CustomBits
+// value larger than math.MaxInt64 returns an error, as do values boxed with
+// box.Any that have no driver type.
+func (v Value) Value() (driver.Value, error) {
+	v = v.unwrap()
+	switch v.ptr {
+	case nil:
+		return nil, nil
+	case boolType:
+		return v.ext != 0, nil
+	case int64Type, durationType:
+		return int64(v.ext), nil
+	case uint64Type, custBitsType, idType:
+		if v.ext > math.MaxInt64 {
+			return nil, fmt.Errorf("box: uint64 value %d overflows int64",
+				v.ext)
+		}
+		return int64(v.ext), nil
+	case float64Type, percentType:
+		return math.Float64frombits(v.ext), nil
+	case timeType:
+		return v.Time(), nil
+	case runeType, colorType, enumSetType, semverType:
+		return v.String(), nil
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return v.assertString(), nil
+	case ptrBytes:
+		return v.assertBytes(), nil
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case string, []byte, time.Time:
+		return vf, nil
+	case *taggedString:
+		return vf.str, nil
+	case *lazyBytes:
+		return vf.bytes(), nil
+	case *taggedBytes:
+		return vf.bytes, nil
+	case *enumSet:
+		return vf.String(), nil
+	case driver.Valuer:
+		return vf.Value()
+	default:
+		return nil, fmt.Errorf("box: cannot convert %T to a driver value",
+			vf)
+	}
+}
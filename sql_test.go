@@ -0,0 +1,131 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+var _ sql.Scanner = (*Value)(nil)
+var _ driver.Valuer = Value{}
+
+// fakeDriver is a database/sql driver that stores the arguments of the last
+// Exec and returns them as a single row from Query.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{}, nil
+}
+
+type fakeConn struct{ row []driver.Value }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c}, nil
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type fakeStmt struct{ c *fakeConn }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.row = args
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{row: s.c.row}, nil
+}
+
+type fakeRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (r *fakeRows) Columns() []string { return make([]string, len(r.row)) }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.row)
+	return nil
+}
+
+func init() {
+	sql.Register("boxfake", fakeDriver{})
+}
+
+func TestSQLScan(t *testing.T) {
+	var v Value
+	assert(v.Scan(int64(-5)) == nil && v.IsInt() && v.Int64() == -5)
+	assert(v.Scan(1.5) == nil && v.IsFloat() && v.Float64() == 1.5)
+	assert(v.Scan(true) == nil && v.IsBool() && v.Bool())
+	assert(v.Scan("hello") == nil && v.IsString() && v.String() == "hello")
+	tm := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert(v.Scan(tm) == nil && v.IsTime() && v.Time() == tm)
+	assert(v.Scan(nil) == nil && v.IsNil())
+	assert(v.Scan(Jello{1, 2}) == nil && v.Any().(Jello).Neat == 1)
+
+	// drivers reuse []byte buffers between rows
+	buf := []byte("hello")
+	assert(v.Scan(buf) == nil && v.IsBytes())
+	copy(buf, "jello")
+	assert(v.String() == "hello")
+	assert(v.Scan([]byte{}) == nil && v.Len() == 0)
+}
+
+func TestSQLValue(t *testing.T) {
+	tm := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	vals := []Value{Nil(), Bool(true), Int64(-5), Uint64(5), Float64(1.5),
+		String("hello"), Bytes([]byte("hello")), Time(tm)}
+	db, err := sql.Open("boxfake", "")
+	assert(err == nil)
+	defer db.Close()
+	args := make([]any, len(vals))
+	for i := range vals {
+		args[i] = vals[i]
+	}
+	_, err = db.Exec("insert", args...)
+	assert(err == nil)
+	out := make([]Value, len(vals))
+	dest := make([]any, len(vals))
+	for i := range out {
+		dest[i] = &out[i]
+	}
+	assert(db.QueryRow("select").Scan(dest...) == nil)
+	assert(out[0].IsNil())
+	assert(out[1].IsBool() && out[1].Bool())
+	assert(out[2].IsInt() && out[2].Int64() == -5)
+	assert(out[3].IsInt() && out[3].Int64() == 5)
+	assert(out[4].IsFloat() && out[4].Float64() == 1.5)
+	assert(out[5].IsString() && out[5].String() == "hello")
+	assert(out[6].IsBytes() && out[6].String() == "hello")
+	assert(out[7].IsTime() && out[7].Time() == tm)
+
+	_, err = db.Exec("insert", Uint64(math.MaxUint64))
+	assert(err != nil)
+	_, err = db.Exec("insert", Any(Jello{1, 2}))
+	assert(err != nil)
+
+	dv, err := StringWithTag("hello", 1).Value()
+	assert(err == nil && dv.(string) == "hello")
+	dv, err = Duration(time.Second).Value()
+	assert(err == nil && dv.(int64) == int64(time.Second))
+	forceIfaceStrs = true
+	dv, err = String("hello").Value()
+	assert(err == nil && dv.(string) == "hello")
+	dv, err = Bytes([]byte("hello")).Value()
+	assert(err == nil && string(dv.([]byte)) == "hello")
+	forceIfaceStrs = false
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+// Handlers holds the optional callbacks used by Value.Switch. A nil field
+// is skipped, and the value is passed to OnAny instead.
+type Handlers struct {
+	OnNil    func()
+	OnBool   func(bool)
+	OnInt    func(int64)
+	OnUint   func(uint64)
+	OnFloat  func(float64)
+	OnString func(string)
+	OnBytes  func([]byte)
+	OnAny    func(any)
+}
+
+// Switch calls the handler that matches the kind of the value, saving the
+// type switch that usually follows unboxing.
+//
+// KindNil, KindBool, KindInt, KindUint, KindFloat, KindString, and KindBytes
+// go to OnNil, OnBool, OnInt, OnUint, OnFloat, OnString, and OnBytes. Every
+// other kind, and every kind whose handler is nil, goes to OnAny with the
+// result of Value.Any. When OnAny is also nil, nothing is called. At most
+// one handler is called.
+func (v Value) Switch(h Handlers) {
+	switch v.Kind() {
+	case KindNil:
+		if h.OnNil != nil {
+			h.OnNil()
+			return
+		}
+	case KindBool:
+		if h.OnBool != nil {
+			h.OnBool(v.Bool())
+			return
+		}
+	case KindInt:
+		if h.OnInt != nil {
+			h.OnInt(v.Int64())
+			return
+		}
+	case KindUint:
+		if h.OnUint != nil {
+			h.OnUint(v.Uint64())
+			return
+		}
+	case KindFloat:
+		if h.OnFloat != nil {
+			h.OnFloat(v.Float64())
+			return
+		}
+	case KindString:
+		if h.OnString != nil {
+			h.OnString(v.String())
+			return
+		}
+	case KindBytes:
+		if h.OnBytes != nil {
+			h.OnBytes(v.Bytes())
+			return
+		}
+	}
+	if h.OnAny != nil {
+		h.OnAny(v.Any())
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSwitch(t *testing.T) {
+	var calls []string
+	var got any
+	all := Handlers{
+		OnNil:    func() { calls = append(calls, "nil"); got = nil },
+		OnBool:   func(x bool) { calls = append(calls, "bool"); got = x },
+		OnInt:    func(x int64) { calls = append(calls, "int"); got = x },
+		OnUint:   func(x uint64) { calls = append(calls, "uint"); got = x },
+		OnFloat:  func(x float64) { calls = append(calls, "float"); got = x },
+		OnString: func(x string) { calls = append(calls, "string"); got = x },
+		OnBytes: func(x []byte) {
+			calls = append(calls, "bytes")
+			got = string(x)
+		},
+		OnAny: func(x any) { calls = append(calls, "any"); got = x },
+	}
+	tm := time.Unix(1, 0)
+	for _, c := range []struct {
+		v    Value
+		call string
+		x    any
+	}{
+		{Nil(), "nil", nil},
+		{Bool(true), "bool", true},
+		{Int(-5), "int", int64(-5)},
+		{Int8(-5), "int", int64(-5)},
+		{Uint(5), "uint", uint64(5)},
+		{Float64(1.5), "float", 1.5},
+		{Float32(1.5), "float", 1.5},
+		{String("hi"), "string", "hi"},
+		{StringWithTag("hi", 1), "string", "hi"},
+		{Bytes([]byte("hi")), "bytes", "hi"},
+		{String("hi").WithCorrelation(1), "string", "hi"},
+		{Time(tm), "any", tm},
+		{Duration(time.Second), "any", time.Second},
+		{Any(Jello{}), "any", Jello{}},
+	} {
+		calls, got = nil, "unset"
+		c.v.Switch(all)
+		if len(calls) != 1 || calls[0] != c.call || got != c.x {
+			t.Fatalf("%v: expected %s %v, got %v %v", c.v, c.call, c.x,
+				calls, got)
+		}
+	}
+
+	// OnAny is the fallback for missing handlers
+	calls = nil
+	Int(7).Switch(Handlers{
+		OnString: func(string) { calls = append(calls, "string") },
+		OnAny:    func(x any) { calls = append(calls, "any"); got = x },
+	})
+	assert(len(calls) == 1 && calls[0] == "any" && got == int64(7))
+	calls = nil
+	Nil().Switch(Handlers{OnAny: func(x any) {
+		calls = append(calls, "any")
+		got = x
+	}})
+	assert(len(calls) == 1 && got == nil)
+
+	// no handlers is a no-op
+	String("hi").Switch(Handlers{})
+	Nil().Switch(Handlers{OnInt: func(int64) { t.Fatal("called") }})
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "time"
+
+// Time boxes a time.Time
+//
+// A UTC time without a monotonic clock reading, between the years 1678 and
+// 2262, is stored inline as Unix nanoseconds and does not allocate. Any
+// other time, such as one with a non-UTC location, a monotonic reading, or
+// a value outside of that range, is boxed as-is using the same path as
+// box.Any.
+func Time(t time.Time) Value {
+	if t.Location() == time.UTC && t == t.Round(0) {
+		nsec := t.UnixNano()
+		if time.Unix(0, nsec).UTC() == t {
+			return Value{uint64(nsec), timeType}
+		}
+	}
+	return toIface(t)
+}
+
+// Time returns the value as a time.Time.
+// Strings and byte slices are parsed using the RFC 3339 format.
+// Returns the zero time for values that cannot be converted.
+func (v Value) Time() time.Time {
+	v = v.unwrap()
+	if v.ptr == timeType {
+		return time.Unix(0, int64(v.ext)).UTC()
+	}
+	if v.isPrim() {
+		return time.Time{}
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case time.Time:
+		return vf
+	case string, []byte, *taggedString:
+		t, err := time.Parse(time.RFC3339Nano, v.String())
+		if err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// IsTime returns true if the boxed value is a time.Time.
+func (v Value) IsTime() bool {
+	v = v.unwrap()
+	if v.ptr == timeType {
+		return true
+	}
+	if v.isPrim() {
+		return false
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(time.Time)
+	return ok
+}
+
+// Duration boxes a time.Duration
+// The duration is stored inline and does not allocate.
+func Duration(d time.Duration) Value {
+	return Value{uint64(d), durationType}
+}
+
+// Duration returns the value as a time.Duration.
+// Strings and byte slices are parsed using time.ParseDuration, and other
+// values are converted using Int64() as a number of nanoseconds.
+func (v Value) Duration() time.Duration {
+	v = v.unwrap()
+	if v.ptr == durationType {
+		return time.Duration(v.ext)
+	}
+	if !v.isPrim() {
+		switch v.assertNonPrimAny().(type) {
+		case string, []byte, *taggedString:
+			d, err := time.ParseDuration(v.String())
+			if err == nil {
+				return d
+			}
+			return 0
+		}
+	}
+	return time.Duration(v.Int64())
+}
+
+// IsDuration returns true if the boxed value is a time.Duration.
+func (v Value) IsDuration() bool { return v.unwrap().ptr == durationType }
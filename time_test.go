@@ -0,0 +1,99 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	tm := time.Date(2023, 4, 5, 6, 7, 8, 9, time.UTC)
+	v := Time(tm)
+	assert(v.ptr == timeType)
+	assert(v.IsTime())
+	assert(v.Time() == tm)
+	assert(v.Any().(time.Time) == tm)
+	assert(v.String() == "2023-04-05T06:07:08.000000009Z")
+	assert(v.Int64() == tm.UnixNano())
+	assert(v.Bool())
+	assert(Any(tm).ptr == timeType)
+	assert(Any(tm).Time() == tm)
+	assert(v.Type() == reflect.TypeOf(tm))
+	assert(allocs(func() { v = Time(tm) }) == 0)
+
+	// monotonic, non-UTC, and out of range times take the iface path
+	now := time.Now()
+	v = Time(now)
+	assert(v.ptr != timeType && v.IsTime())
+	assert(v.Time() == now)
+	loc := time.FixedZone("X", 3600)
+	v = Time(tm.In(loc))
+	assert(v.ptr != timeType && v.IsTime())
+	assert(v.Time().Location() == loc)
+	v = Time(time.Time{})
+	assert(v.ptr != timeType && v.IsTime())
+	assert(v.Time().IsZero())
+	far := time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	v = Time(far)
+	assert(v.ptr != timeType && v.Time() == far)
+
+	assert(String("2023-04-05T06:07:08.000000009Z").Time().Equal(tm))
+	assert(Bytes([]byte("2023-04-05T06:07:08Z")).Time().Unix() == tm.Unix())
+	assert(String("hello").Time().IsZero())
+	assert(Int(1).Time().IsZero())
+	assert(Nil().Time().IsZero())
+	assert(!String("2023-04-05T06:07:08Z").IsTime())
+	assert(!Int(1).IsTime())
+
+	for _, v := range []Value{Time(tm), Time(tm.In(loc)), Time(now)} {
+		data, err := v.MarshalBinary()
+		assert(err == nil)
+		var v2 Value
+		assert(v2.UnmarshalBinary(data) == nil)
+		assert(v2.IsTime() && v2.Time().Equal(v.Time()))
+	}
+	data, _ := Time(tm).MarshalBinary()
+	for i := 0; i < len(data); i++ {
+		var v2 Value
+		assert(v2.UnmarshalBinary(data[:i]) != nil)
+	}
+}
+
+func allocs(fn func()) float64 {
+	return testing.AllocsPerRun(100, fn)
+}
+
+func TestDuration(t *testing.T) {
+	d := 1500 * time.Millisecond
+	v := Duration(d)
+	assert(v.IsDuration())
+	assert(!v.IsInt())
+	assert(v.Duration() == d)
+	assert(v.Int64() == int64(d))
+	assert(v.Any().(time.Duration) == d)
+	assert(v.String() == "1.5s")
+	assert(Duration(90*time.Second).String() == "1m30s")
+	assert(Duration(-time.Minute).String() == "-1m0s")
+	assert(v.Type() == reflect.TypeOf(d))
+	assert(Any(d).IsDuration())
+	assert(allocs(func() { v = Duration(d) }) == 0)
+	assert(allocs(func() { v = Any(d) }) == 0)
+
+	// plain int64 boxing is unaffected
+	assert(Int64(int64(d)).IsInt())
+	assert(!Int64(int64(d)).IsDuration())
+	assert(Int64(int64(d)).String() == "1500000000")
+	assert(Int64(int64(d)).Duration() == d)
+	assert(String("1m30s").Duration() == 90*time.Second)
+	assert(String("hello").Duration() == 0)
+
+	data, err := v.MarshalBinary()
+	assert(err == nil)
+	var v2 Value
+	assert(v2.UnmarshalBinary(data) == nil)
+	assert(v2.IsDuration() && v2.Duration() == d)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"reflect"
+)
+
+// Truthy returns true if the value is "truthy", using rules like those of
+// JavaScript, which are meant for templates and expressions. Unlike Bool,
+// strings are never parsed, so "false" and "0" are truthy.
+//
+//	Nil                               false
+//	Bool                              the bool
+//	integers, IDs, durations, runes   not zero
+//	floats and percents               not zero and not NaN
+//	complex numbers                   not zero
+//	times, colors, and semvers        true
+//	enum sets                         not empty
+//	strings and byte slices           not empty
+//	slices, arrays, and maps          not empty, including box.Slice,
+//	                                  box.Map, and box.RunLength values
+//	typed nil pointers, maps, etc.    false
+//	any other value                   true
+//
+// A value from WithCorrelation is truthy when the value it wraps is.
+func (v Value) Truthy() bool {
+	switch v.ptr {
+	case nil:
+		return false
+	case float64Type, percentType, float32Type:
+		f := math.Float64frombits(v.ext)
+		return f != 0 && f == f
+	case timeType, colorType, semverType:
+		return true
+	case complex64Type:
+		return v.complex64() != 0
+	}
+	if v.isPrim() {
+		return v.ext != 0
+	}
+	switch v.ext & 0xFF {
+	case ptrString, ptrBytes:
+		return v.ext>>32 != 0
+	}
+	switch vf := v.assertNonPrimAny().(type) {
+	case *correlated:
+		return vf.v.Truthy()
+	case *valueMap:
+		return len(vf.keys) > 0
+	case *enumSet:
+		return len(vf.codes) > 0
+	case complex128:
+		return vf != 0
+	case string, []byte, *taggedString, *taggedBytes, *lazyBytes,
+		*runLength:
+		return v.Len() > 0
+	default:
+		if v.IsNilPointer() {
+			return false
+		}
+		switch rv := reflect.ValueOf(vf); rv.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+			return rv.Len() > 0
+		}
+		return true
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTruthy(t *testing.T) {
+	truthy := []Value{
+		Bool(true), Int(1), Int(-1), Uint(1), Float64(0.5),
+		Float64(math.Inf(-1)), Percent(0.1), CustomBits(1), ID(1),
+		Duration(1), Rune('a'), Time(time.Time{}), RGBA(0, 0, 0, 0),
+		SemVer(0, 0, 0), EnumSet(0), EnumSet(100), String("false"),
+		String("0"), String(" "), Bytes([]byte{0}),
+		StringWithTag("x", 1), BytesWithTag([]byte("x"), 1),
+		Any([]int{0}), Any([1]int{}), Any(map[string]int{"a": 0}),
+		Slice([]Value{Nil()}), Map([]Value{Int(0)}, []Value{Int(0)}),
+		RunLength(Nil(), 1), Any(Jello{}), Any(&Jello{}), Any(make(chan int)),
+		LazyBytes(strings.NewReader("x"), 0, 1), Complex128(1i),
+		Int(1).WithCorrelation(1),
+	}
+	falsy := []Value{
+		Nil(), Bool(false), Int(0), Uint(0), Float64(0),
+		Float64(math.Copysign(0, -1)), Float64(math.NaN()), Percent(0),
+		Percent(math.NaN()), CustomBits(0), ID(0), Duration(0), Rune(0),
+		EnumSet(), String(""), Bytes(nil), Bytes([]byte{}),
+		StringWithTag("", 1), Any([]int{}), Any([0]int{}),
+		Any(map[string]int{}), Slice(nil), Map(nil, nil),
+		RunLength(Int(1), 0), Any((*Jello)(nil)), Any([]int(nil)),
+		Any(map[string]int(nil)), LazyBytes(strings.NewReader(""), 0, 0),
+		Complex128(0), Int(0).WithCorrelation(1),
+		String("").WithCorrelation(1),
+	}
+	for i, v := range truthy {
+		if !v.Truthy() {
+			t.Fatalf("truthy %d: %#v", i, v)
+		}
+	}
+	for i, v := range falsy {
+		if v.Truthy() {
+			t.Fatalf("falsy %d: %#v", i, v)
+		}
+	}
+	forceIfaceStrs = true
+	assert(String("x").Truthy() && !String("").Truthy())
+	assert(Bytes([]byte("x")).Truthy() && !Bytes(nil).Truthy())
+	forceIfaceStrs = false
+
+	// Bool parses strings, Truthy does not
+	assert(!String("false").Bool() && String("false").Truthy())
+	assert(!String("yes").Bool() && String("yes").Truthy())
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import "unsafe"
+
+// Valid reports whether the internal fields of the value hold a layout that
+// one of the box constructors could have produced. Every value created by
+// this package is valid, but a Value that was built by other means, such as
+// by unsafe code or from corrupted memory, may not be, and using it could
+// crash the program. Valid lets defensive code check such a value before
+// calling other methods. It does not allocate, and does not follow the
+// pointer of the value.
+//
+// The invariants checked are:
+//
+//   - A value without a pointer is Nil, with no other bits set, or a typed
+//     nil from box.Any, which keeps its interface type.
+//   - A primitive value points exactly to one of the package's type
+//     sentinels, and its bits are in range for that type: a Bool is 0 or 1,
+//     a Rune fits in an int32, an RGBA fits in 32 bits, and a SemVer fits in
+//     48 bits.
+//   - Any other value has a known layout tag in its low 8 bits.
+//   - A string, byte slice, []float64, or []int64 stored inline has a length
+//     of at most 2147483647. A string has no bits set between its tag and
+//     its length, and a []float64 or []int64 is not a tagged slice and is
+//     aligned to 8 bytes when not empty.
+//   - A value boxed using an inline interface type has a non-zero type, and
+//     a value boxed using a pointer to an interface has no type bits and a
+//     pointer aligned to the size of a pointer.
+//
+// Valid cannot tell whether the pointer of the value refers to live memory
+// of the right type, so a value that passes is not proven to be safe.
+func (v Value) Valid() bool {
+	if v.ptr == nil {
+		return v.ext == 0 || (v.ext&0xFF == ptrIface && v.ext>>8 != 0)
+	}
+	if v.isPrim() {
+		switch v.ptr {
+		case boolType:
+			return v.ext <= 1
+		case runeType, int32Type:
+			return int64(v.ext) == int64(int32(v.ext))
+		case int8Type:
+			return int64(v.ext) == int64(int8(v.ext))
+		case int16Type:
+			return int64(v.ext) == int64(int16(v.ext))
+		case uint8Type:
+			return v.ext>>8 == 0
+		case uint16Type:
+			return v.ext>>16 == 0
+		case uint32Type:
+			return v.ext>>32 == 0
+		case colorType:
+			return v.ext>>32 == 0
+		case semverType:
+			return v.ext>>48 == 0
+		}
+		return true
+	}
+	switch v.ext & 0xFF {
+	case ptrString:
+		return v.ext>>32 <= maxLen && v.ext&0xFF000000 == 0
+	case ptrBytes:
+		return v.ext>>32 <= maxLen
+	case ptrFloat64s, ptrInt64s:
+		return v.ext>>32 <= maxLen && v.ext&bytesTagged == 0 &&
+			(v.ext>>32 == 0 || uintptr(v.ptr)%8 == 0)
+	case ptrIface:
+		return v.ext>>8 != 0
+	case ptrIfacePtr:
+		return v.ext>>8 == 0 &&
+			uintptr(v.ptr)%unsafe.Alignof(uintptr(0)) == 0
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package box
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestValid(t *testing.T) {
+	for _, v := range []Value{
+		{}, Nil(), Bool(true), Bool(false), Int(-1), Uint(math.MaxUint64),
+		Float64(math.NaN()), CustomBits(math.MaxUint64), Time(time.Now()),
+		Percent(0.5), Duration(-1), ID(math.MaxUint64), Rune(-1),
+		Rune(math.MaxInt32), RGBA(255, 255, 255, 255),
+		SemVer(65535, 65535, 65535), EnumSet(0, 63), String(""),
+		String("hello"), StringWithTag("hi", 65535), Bytes(nil),
+		Bytes(make([]byte, 3, 1000)), BytesWithTag(make([]byte, 1, 100), 9),
+		Float64Slice([]float64{1}), Float64Slice(nil), Int64Slice([]int64{1}),
+		Any(Jello{}), Any((*Jello)(nil)), Any(map[string]int(nil)),
+		AnyUnpinned(Jello{}), Complex128(1i), AnyExact(int8(1)),
+		LazyBytes(strings.NewReader("x"), 0, 1), Int(1).WithCorrelation(1),
+		RunLength(Int(1), 3), Slice([]Value{Int(1)}),
+	} {
+		if !v.Valid() {
+			t.Fatalf("%#v: expected valid", v)
+		}
+	}
+	forceIfacePtrs = true
+	assert(Any(Jello{}).Valid() && Any((*Jello)(nil)).Valid())
+	forceIfacePtrs = false
+
+	var x [4]uint64
+	p := unsafe.Pointer(&x[0])
+	for i, v := range []Value{
+		{ext: 1},
+		{ext: ptrString},
+		{ext: 5 << 32},
+		{ext: 2, ptr: boolType},
+		{ext: 1 << 32, ptr: runeType},
+		{ext: 1 << 32, ptr: colorType},
+		{ext: 1 << 48, ptr: semverType},
+		{ext: 0, ptr: p},
+		{ext: 7, ptr: p},
+		{ext: 0xFF, ptr: p},
+		{ext: 0x80000000<<32 | ptrString, ptr: p},
+		{ext: 1<<32 | 1<<24 | ptrString, ptr: p},
+		{ext: 0x80000000<<32 | ptrBytes, ptr: p},
+		{ext: 1<<32 | bytesTagged | ptrFloat64s, ptr: p},
+		{ext: 1<<32 | ptrInt64s, ptr: unsafe.Add(p, 1)},
+		{ext: ptrIface, ptr: p},
+		{ext: 1<<8 | ptrIfacePtr, ptr: p},
+		{ext: ptrIfacePtr, ptr: unsafe.Add(p, 1)},
+	} {
+		if v.Valid() {
+			t.Fatalf("%d: expected invalid", i)
+		}
+	}
+	assert(allocs(func() { _ = String("hi").Valid() }) == 0)
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.24
+
+package box
+
+import "weak"
+
+type weakRef interface{ strong() (any, bool) }
+
+type weakPtr[T any] struct{ p weak.Pointer[T] }
+
+func (w *weakPtr[T]) strong() (any, bool) {
+	p := w.p.Value()
+	return p, p != nil
+}
+
+// Weak boxes a weak reference to the object that p points to.
+// The reference does not keep the object alive, so once there are no other
+// references to it the object may be garbage collected. Use Strong to get
+// the object back, if it's still live.
+// A nil p boxes a reference that is always gone.
+func Weak[T any](p *T) Value {
+	return toIface(&weakPtr[T]{weak.Make(p)})
+}
+
+// Strong returns the object from a value created by box.Weak, boxed as a
+// pointer. Returns false if the object was garbage collected, or if the
+// value is not a weak reference. The returned value is a normal, strong
+// reference that keeps the object alive.
+func (v Value) Strong() (Value, bool) {
+	v = v.unwrap()
+	if v.isPrim() {
+		return Nil(), false
+	}
+	w, ok := v.assertNonPrimAny().(weakRef)
+	if !ok {
+		return Nil(), false
+	}
+	p, ok := w.strong()
+	if !ok {
+		return Nil(), false
+	}
+	return Any(p), true
+}
+
+// IsWeak returns true if the boxed value was created using box.Weak.
+func (v Value) IsWeak() bool {
+	v = v.unwrap()
+	if v.isPrim() {
+		return false
+	}
+	_, ok := v.assertNonPrimAny().(weakRef)
+	return ok
+}
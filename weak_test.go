@@ -0,0 +1,41 @@
+// Copyright 2023 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.24
+
+package box
+
+import (
+	"runtime"
+	"testing"
+)
+
+type bigThing struct {
+	name string
+	data [1 << 16]byte
+}
+
+func TestWeak(t *testing.T) {
+	obj := &bigThing{name: "big"}
+	v := Weak(obj)
+	assert(v.IsWeak())
+	s, ok := v.Strong()
+	assert(ok && s.Any().(*bigThing) == obj)
+	runtime.GC()
+	s, ok = v.Strong()
+	assert(ok && s.Any().(*bigThing).name == "big")
+	runtime.KeepAlive(obj)
+
+	s = Nil()
+	obj = nil
+	runtime.GC()
+	s, ok = v.Strong()
+	assert(!ok && s.IsNil())
+
+	_, ok = Weak[bigThing](nil).Strong()
+	assert(!ok)
+	assert(!Int(1).IsWeak() && !Any(&bigThing{}).IsWeak())
+	_, ok = Any(&bigThing{}).Strong()
+	assert(!ok)
+}